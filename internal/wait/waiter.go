@@ -0,0 +1,124 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Package wait implements a generic poller for Oxide API resources that
+// change state asynchronously (instances starting/stopping, attachments
+// settling, etc). It is modeled on the GCP provider's
+// ComputeOperationWaiter / resource.StateChangeConf pattern: callers supply
+// a RefreshFunc that reports the current state of a resource, along with
+// the states that are expected in-flight and the states that indicate
+// success, and the waiter polls until a target state is reached, the
+// context is canceled, or the timeout elapses.
+package wait
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// RefreshFunc fetches the latest state of the resource being waited on. It
+// returns the resource itself (so callers don't need a second fetch once the
+// wait succeeds), a string describing its current state, and an error if the
+// fetch failed. A nil result with a nil error is treated as "not found yet".
+type RefreshFunc func() (result any, state string, err error)
+
+// Waiter polls a RefreshFunc until it reaches one of Target's states.
+//
+// Timeout bounds the overall wait. Delay is how long to wait before the
+// first poll, to give an just-issued API call time to take effect.
+// PollInterval is the interval used for the first poll; MinTimeout is the
+// ceiling that interval backs off to, doubling on each subsequent poll until
+// it is reached, mirroring resource.StateChangeConf's backoff.
+//
+// NotFoundChecks caps the number of consecutive "not found" results
+// (Refresh returning a nil result and nil error) that are tolerated before
+// the waiter gives up. This lets deletion flows ride out eventually
+// consistent 404s instead of failing on the first one.
+type Waiter struct {
+	// Pending is the set of states expected while the operation is still
+	// in flight.
+	Pending []string
+	// Target is the set of states that indicate the operation has
+	// completed successfully.
+	Target []string
+
+	Refresh RefreshFunc
+
+	Timeout      time.Duration
+	Delay        time.Duration
+	MinTimeout   time.Duration
+	PollInterval time.Duration
+
+	NotFoundChecks int
+}
+
+// WaitForStateContext polls Refresh until it reports one of the Target
+// states, returning the final result. It returns an error if ctx is
+// canceled, the Timeout elapses, Refresh returns an unexpected state, or
+// NotFoundChecks is exceeded.
+func (w *Waiter) WaitForStateContext(ctx context.Context) (any, error) {
+	ctx, cancel := context.WithTimeout(ctx, w.Timeout)
+	defer cancel()
+
+	pollInterval := w.PollInterval
+	if pollInterval == 0 {
+		pollInterval = w.MinTimeout
+	}
+
+	if w.Delay > 0 {
+		select {
+		case <-time.After(w.Delay):
+		case <-ctx.Done():
+			return nil, fmt.Errorf("waiting for state: %w", ctx.Err())
+		}
+	}
+
+	notFoundChecks := 0
+
+	for {
+		result, state, err := w.Refresh()
+		if err != nil {
+			return nil, fmt.Errorf("waiting for state: %w", err)
+		}
+
+		if result == nil {
+			notFoundChecks++
+			if notFoundChecks > w.NotFoundChecks {
+				return nil, fmt.Errorf("waiting for state: resource not found after %d checks", notFoundChecks)
+			}
+		} else {
+			notFoundChecks = 0
+
+			if contains(w.Target, state) {
+				return result, nil
+			}
+
+			if !contains(w.Pending, state) && len(w.Pending) > 0 {
+				return nil, fmt.Errorf("waiting for state: unexpected state %q, wanted one of %v", state, w.Target)
+			}
+		}
+
+		select {
+		case <-time.After(pollInterval):
+			if pollInterval < w.MinTimeout {
+				pollInterval *= 2
+				if pollInterval > w.MinTimeout {
+					pollInterval = w.MinTimeout
+				}
+			}
+		case <-ctx.Done():
+			return nil, fmt.Errorf("waiting for state: %w", ctx.Err())
+		}
+	}
+}
+
+func contains(states []string, state string) bool {
+	for _, s := range states {
+		if s == state {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,332 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package oxide
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	oxideSDK "github.com/oxidecomputer/oxide.go/oxide"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = (*floatingIPResource)(nil)
+	_ resource.ResourceWithConfigure = (*floatingIPResource)(nil)
+)
+
+// NewFloatingIPResource is a helper function to simplify the provider implementation.
+func NewFloatingIPResource() resource.Resource {
+	return &floatingIPResource{}
+}
+
+// floatingIPResource is the resource implementation.
+//
+// Unlike the external_ips block on oxide_instance, a floating IP has its
+// own lifecycle independent of any instance: it is allocated here and can
+// be attached to and detached from instances (via oxide_instance's
+// external_ips) any number of times before it is released.
+type floatingIPResource struct {
+	client *oxideSDK.Client
+}
+
+type floatingIPResourceModel struct {
+	Address     types.String   `tfsdk:"address"`
+	Description types.String   `tfsdk:"description"`
+	ID          types.String   `tfsdk:"id"`
+	Name        types.String   `tfsdk:"name"`
+	PoolName    types.String   `tfsdk:"pool_name"`
+	ProjectID   types.String   `tfsdk:"project_id"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
+	TimeCreated types.String   `tfsdk:"time_created"`
+}
+
+// Metadata returns the resource type name.
+func (r *floatingIPResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "oxide_floating_ip"
+}
+
+// Configure adds the provider configured client to the data source.
+func (r *floatingIPResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*oxideSDK.Client)
+}
+
+func (r *floatingIPResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// Schema defines the schema for the resource.
+func (r *floatingIPResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Allocates a floating IP for use with oxide_instance's external_ips, independent of any " +
+			"particular instance's lifecycle.",
+		Attributes: map[string]schema.Attribute{
+			"project_id": schema.StringAttribute{
+				Required:    true,
+				Description: "ID of the project that will contain the floating IP.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the floating IP.",
+			},
+			"description": schema.StringAttribute{
+				Required:    true,
+				Description: "Description for the floating IP.",
+			},
+			"pool_name": schema.StringAttribute{
+				Optional:    true,
+				Description: "IP pool to allocate the address from. Conflicts with address. Defaults to the silo's default pool.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("address")),
+				},
+			},
+			"address": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Specific IP address to allocate (bring-your-own-IP). Conflicts with pool_name. If omitted, Oxide assigns the next available address from pool_name.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("pool_name")),
+				},
+			},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Unique, immutable, system-controlled identifier of the floating IP.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"time_created": schema.StringAttribute{
+				Computed:    true,
+				Description: "Timestamp of when this floating IP was created.",
+			},
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *floatingIPResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan floatingIPResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultTimeout())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	params := oxideSDK.FloatingIpCreateParams{
+		Project: oxideSDK.NameOrId(plan.ProjectID.ValueString()),
+		Body: &oxideSDK.FloatingIpCreate{
+			Name:        oxideSDK.Name(plan.Name.ValueString()),
+			Description: plan.Description.ValueString(),
+			Pool:        oxideSDK.NameOrId(plan.PoolName.ValueString()),
+			Ip:          plan.Address.ValueString(),
+		},
+	}
+
+	floatingIP, err := r.client.FloatingIpCreate(params)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating floating IP",
+			"API error: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("created floating IP with ID: %v", floatingIP.Id), map[string]any{"success": true})
+
+	plan.ID = types.StringValue(floatingIP.Id)
+	plan.Address = types.StringValue(floatingIP.Ip)
+	plan.TimeCreated = types.StringValue(floatingIP.TimeCreated.String())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *floatingIPResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state floatingIPResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultTimeout())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	floatingIP, err := r.client.FloatingIpView(oxideSDK.FloatingIpViewParams{
+		FloatingIp: oxideSDK.NameOrId(state.ID.ValueString()),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read floating IP:",
+			"API error: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("read floating IP with ID: %v", floatingIP.Id), map[string]any{"success": true})
+
+	state.ID = types.StringValue(floatingIP.Id)
+	state.Name = types.StringValue(string(floatingIP.Name))
+	state.Description = types.StringValue(floatingIP.Description)
+	state.Address = types.StringValue(floatingIP.Ip)
+	state.ProjectID = types.StringValue(floatingIP.ProjectId)
+	state.TimeCreated = types.StringValue(floatingIP.TimeCreated.String())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+//
+// Only name and description can change without replacing the floating IP;
+// pool_name and address are both RequiresReplace.
+func (r *floatingIPResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state floatingIPResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultTimeout())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	floatingIP, err := r.client.FloatingIpUpdate(oxideSDK.FloatingIpUpdateParams{
+		FloatingIp: oxideSDK.NameOrId(state.ID.ValueString()),
+		Body: &oxideSDK.FloatingIpUpdate{
+			Name:        oxideSDK.Name(plan.Name.ValueString()),
+			Description: plan.Description.ValueString(),
+		},
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to update floating IP:",
+			"API error: "+err.Error(),
+		)
+		return
+	}
+	tflog.Trace(ctx, fmt.Sprintf("updated floating IP with ID: %v", state.ID.ValueString()), map[string]any{"success": true})
+
+	plan.ID = types.StringValue(floatingIP.Id)
+	plan.Address = types.StringValue(floatingIP.Ip)
+	plan.TimeCreated = types.StringValue(floatingIP.TimeCreated.String())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+//
+// If the floating IP is still attached to an instance, Oxide will refuse
+// to release it, so it is detached first the same way oxide_instance
+// detaches disks before deleting the instance they belong to.
+func (r *floatingIPResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state floatingIPResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultTimeout())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	floatingIPID := oxideSDK.NameOrId(state.ID.ValueString())
+
+	floatingIP, err := r.client.FloatingIpView(oxideSDK.FloatingIpViewParams{FloatingIp: floatingIPID})
+	if err != nil {
+		if !is404(err) {
+			resp.Diagnostics.AddError(
+				"Unable to read floating IP:",
+				"API error: "+err.Error(),
+			)
+			return
+		}
+		// Already gone: nothing to detach or delete.
+		return
+	}
+
+	if floatingIP.InstanceId != "" {
+		_, err := r.client.InstanceExternalIpDetach(oxideSDK.InstanceExternalIpDetachParams{
+			Instance: oxideSDK.NameOrId(floatingIP.InstanceId),
+			Body: &oxideSDK.ExternalIpDetach{
+				Type:       oxideSDK.ExternalIpDetachTypeFloating,
+				FloatingIp: floatingIPID,
+			},
+		})
+		if err != nil && !is404(err) {
+			resp.Diagnostics.AddError(
+				"Unable to detach floating IP:",
+				"API error: "+err.Error(),
+			)
+			return
+		}
+		tflog.Trace(ctx, fmt.Sprintf("detached floating IP with ID: %v", state.ID.ValueString()), map[string]any{"success": true})
+	}
+
+	if err := r.client.FloatingIpDelete(oxideSDK.FloatingIpDeleteParams{FloatingIp: floatingIPID}); err != nil {
+		if !is404(err) {
+			resp.Diagnostics.AddError(
+				"Unable to delete floating IP:",
+				"API error: "+err.Error(),
+			)
+			return
+		}
+	}
+	tflog.Trace(ctx, fmt.Sprintf("deleted floating IP with ID: %v", state.ID.ValueString()), map[string]any{"success": true})
+}
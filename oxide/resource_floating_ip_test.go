@@ -0,0 +1,148 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package oxide
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	oxideSDK "github.com/oxidecomputer/oxide.go/oxide"
+)
+
+func TestAccResourceFloatingIP_full(t *testing.T) {
+	resourceName := "oxide_floating_ip.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(),
+		CheckDestroy:             testAccFloatingIPDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceFloatingIPConfig,
+				Check:  checkResourceFloatingIP(resourceName),
+			},
+			{
+				Config: testResourceFloatingIPUpdateConfig,
+				Check:  checkResourceFloatingIPUpdate(resourceName),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+var testResourceFloatingIPConfig = `
+resource "oxide_floating_ip" "test" {
+	project_id  = "terraform-acc-myproject"
+	name        = "terraform-acc-myfloatingip"
+	description = "a test floating ip"
+}
+`
+
+func checkResourceFloatingIP(resourceName string) resource.TestCheckFunc {
+	return resource.ComposeAggregateTestCheckFunc([]resource.TestCheckFunc{
+		resource.TestCheckResourceAttrSet(resourceName, "id"),
+		resource.TestCheckResourceAttr(resourceName, "name", "terraform-acc-myfloatingip"),
+		resource.TestCheckResourceAttr(resourceName, "description", "a test floating ip"),
+		resource.TestCheckResourceAttrSet(resourceName, "address"),
+		resource.TestCheckResourceAttrSet(resourceName, "time_created"),
+	}...)
+}
+
+var testResourceFloatingIPUpdateConfig = `
+resource "oxide_floating_ip" "test" {
+	project_id  = "terraform-acc-myproject"
+	name        = "terraform-acc-myfloatingip2"
+	description = "a new description for floating ip"
+}
+`
+
+func checkResourceFloatingIPUpdate(resourceName string) resource.TestCheckFunc {
+	return resource.ComposeAggregateTestCheckFunc([]resource.TestCheckFunc{
+		resource.TestCheckResourceAttr(resourceName, "name", "terraform-acc-myfloatingip2"),
+		resource.TestCheckResourceAttr(resourceName, "description", "a new description for floating ip"),
+	}...)
+}
+
+// TestAccResourceFloatingIP_detachOnDelete attaches the floating IP to an
+// instance, then removes the oxide_floating_ip resource from config while
+// the instance that holds it is still up; Delete is expected to detach it
+// first rather than failing because the address is still in use.
+func TestAccResourceFloatingIP_detachOnDelete(t *testing.T) {
+	resourceName := "oxide_floating_ip.test"
+	diskID := testAccInstanceDiskID(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(),
+		CheckDestroy:             testAccFloatingIPDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceFloatingIPAttachedConfig(diskID),
+				Check:  checkResourceFloatingIP(resourceName),
+			},
+			{
+				// The instance stays; only the floating IP (and its
+				// attachment) is removed.
+				Config: testResourceInstanceConfig(diskID),
+			},
+		},
+	})
+}
+
+func testResourceFloatingIPAttachedConfig(diskID string) string {
+	return fmt.Sprintf(`
+resource "oxide_floating_ip" "test" {
+	project_id  = "terraform-acc-myproject"
+	name        = "terraform-acc-myfloatingip"
+	description = "a test floating ip"
+}
+
+resource "oxide_instance" "test" {
+	project_id  = "terraform-acc-myproject"
+	name        = "terraform-acc-myinstance"
+	description = "a test instance"
+	host_name   = "terraform-acc-myinstance"
+	memory      = 1073741824
+	ncpus       = 1
+	boot_disk = {
+		disk_id     = %[1]q
+		device_name = "root"
+	}
+	external_ips = [
+		{
+			type           = "floating"
+			floating_ip_id = oxide_floating_ip.test.id
+		},
+	]
+}
+`, diskID)
+}
+
+func testAccFloatingIPDestroy(s *terraform.State) error {
+	client, err := newTestClient()
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "oxide_floating_ip" {
+			continue
+		}
+
+		res, err := client.FloatingIpView(oxideSDK.FloatingIpViewParams{FloatingIp: oxideSDK.NameOrId(rs.Primary.ID)})
+		if err != nil && is404(err) {
+			continue
+		}
+		return fmt.Errorf("floating IP (%v) still exists", &res.Name)
+	}
+
+	return nil
+}
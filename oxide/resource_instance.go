@@ -6,25 +6,36 @@ package oxide
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
-	"strconv"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	oxideSDK "github.com/oxidecomputer/oxide.go/oxide"
+
+	"github.com/oxidecomputer/terraform-provider-oxide/internal/wait"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource              = (*instanceResource)(nil)
-	_ resource.ResourceWithConfigure = (*instanceResource)(nil)
+	_ resource.Resource                 = (*instanceResource)(nil)
+	_ resource.ResourceWithConfigure    = (*instanceResource)(nil)
+	_ resource.ResourceWithUpgradeState = (*instanceResource)(nil)
 )
 
 // NewInstanceResource is a helper function to simplify the provider implementation.
@@ -38,22 +49,92 @@ type instanceResource struct {
 }
 
 type instanceResourceModel struct {
-	AttachToDisks types.List     `tfsdk:"attach_to_disks"`
-	Description   types.String   `tfsdk:"description"`
-	ExternalIPs   types.List     `tfsdk:"external_ips"`
-	HostName      types.String   `tfsdk:"host_name"`
-	ID            types.String   `tfsdk:"id"`
-	Memory        types.Int64    `tfsdk:"memory"`
-	Name          types.String   `tfsdk:"name"`
-	NCPUs         types.Int64    `tfsdk:"ncpus"`
-	ProjectID     types.String   `tfsdk:"project_id"`
-	StartOnCreate types.Bool     `tfsdk:"start_on_create"`
-	TimeCreated   types.String   `tfsdk:"time_created"`
-	TimeModified  types.String   `tfsdk:"time_modified"`
-	Timeouts      timeouts.Value `tfsdk:"timeouts"`
-	UserData      types.String   `tfsdk:"user_data"`
+	AttachedDisk          types.List     `tfsdk:"attached_disk"`
+	BootDisk              types.Object   `tfsdk:"boot_disk"`
+	Description           types.String   `tfsdk:"description"`
+	ExternalIPs           types.List     `tfsdk:"external_ips"`
+	HostName              types.String   `tfsdk:"host_name"`
+	ID                    types.String   `tfsdk:"id"`
+	Memory                types.Int64    `tfsdk:"memory"`
+	MetadataStartupScript types.String   `tfsdk:"metadata_startup_script"`
+	Name                  types.String   `tfsdk:"name"`
+	NCPUs                 types.Int64    `tfsdk:"ncpus"`
+	PreserveRunState      types.Bool     `tfsdk:"preserve_run_state"`
+	ProjectID             types.String   `tfsdk:"project_id"`
+	StartOnCreate         types.Bool     `tfsdk:"start_on_create"`
+	TimeCreated           types.String   `tfsdk:"time_created"`
+	TimeModified          types.String   `tfsdk:"time_modified"`
+	Timeouts              timeouts.Value `tfsdk:"timeouts"`
+	UserData              types.String   `tfsdk:"user_data"`
+}
+
+// instanceBootDiskModel is the boot_disk singleton block. Exactly one of
+// DiskID (attach an existing disk) or InitializeParams (create a new disk
+// in the same apply) is meaningful at a time.
+type instanceBootDiskModel struct {
+	DiskID           types.String `tfsdk:"disk_id"`
+	DeviceName       types.String `tfsdk:"device_name"`
+	AutoDelete       types.Bool   `tfsdk:"auto_delete"`
+	InitializeParams types.Object `tfsdk:"initialize_params"`
+}
+
+// instanceBootDiskInitializeParamsModel is boot_disk's initialize_params
+// sub-block, used to create the boot disk from an image or snapshot as
+// part of instance creation instead of attaching a pre-existing disk.
+type instanceBootDiskInitializeParamsModel struct {
+	Size             types.Int64  `tfsdk:"size"`
+	SourceImageID    types.String `tfsdk:"source_image_id"`
+	SourceSnapshotID types.String `tfsdk:"source_snapshot_id"`
+	DiskName         types.String `tfsdk:"disk_name"`
+}
+
+// instanceAttachedDiskModel is a single entry of the repeatable
+// attached_disk block.
+type instanceAttachedDiskModel struct {
+	DiskID     types.String `tfsdk:"disk_id"`
+	DeviceName types.String `tfsdk:"device_name"`
+	Mode       types.String `tfsdk:"mode"`
+}
+
+// instanceExternalIPModel is a single entry of the repeatable external_ips
+// block. Exactly one of pool_name (type "ephemeral") or floating_ip_id
+// (type "floating") is meaningful at a time; address is always Computed,
+// populated on Read with whatever address Oxide actually assigned.
+type instanceExternalIPModel struct {
+	Type         types.String `tfsdk:"type"`
+	PoolName     types.String `tfsdk:"pool_name"`
+	FloatingIPID types.String `tfsdk:"floating_ip_id"`
+	Address      types.String `tfsdk:"address"`
 }
 
+// Attribute type maps used to convert boot_disk/attached_disk/external_ips
+// between their schema representation and the Go models above.
+var (
+	bootDiskInitParamsAttrTypes = map[string]attr.Type{
+		"size":               types.Int64Type,
+		"source_image_id":    types.StringType,
+		"source_snapshot_id": types.StringType,
+		"disk_name":          types.StringType,
+	}
+	bootDiskAttrTypes = map[string]attr.Type{
+		"disk_id":           types.StringType,
+		"device_name":       types.StringType,
+		"auto_delete":       types.BoolType,
+		"initialize_params": types.ObjectType{AttrTypes: bootDiskInitParamsAttrTypes},
+	}
+	attachedDiskAttrTypes = map[string]attr.Type{
+		"disk_id":     types.StringType,
+		"device_name": types.StringType,
+		"mode":        types.StringType,
+	}
+	externalIPAttrTypes = map[string]attr.Type{
+		"type":           types.StringType,
+		"pool_name":      types.StringType,
+		"floating_ip_id": types.StringType,
+		"address":        types.StringType,
+	}
+)
+
 // Metadata returns the resource type name.
 func (r *instanceResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = "oxide_instance"
@@ -75,58 +156,158 @@ func (r *instanceResource) ImportState(ctx context.Context, req resource.ImportS
 // Schema defines the schema for the resource.
 func (r *instanceResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		// Bumped 0 -> 1 to replace attach_to_disks with the structured
+		// boot_disk/attached_disk blocks, and 1 -> 2 to replace external_ips'
+		// list-of-pool-names with structured ephemeral/floating entries; see
+		// UpgradeState.
+		Version: 2,
 		Attributes: map[string]schema.Attribute{
 			"project_id": schema.StringAttribute{
 				Required:    true,
 				Description: "ID of the project that will contain the instance.",
 			},
-			"name": schema.StringAttribute{
-				Required:    true,
-				Description: "Name of the instance.",
-			},
-			"description": schema.StringAttribute{
-				Required:    true,
-				Description: "Description for the instance.",
-			},
+			"name":        nameSchemaAttribute("Name of the instance."),
+			"description": descriptionSchemaAttribute(),
 			"host_name": schema.StringAttribute{
 				Required:    true,
 				Description: "Host name of the instance",
 			},
-			"memory": schema.Int64Attribute{
-				Required:    true,
-				Description: "Instance memory in bytes.",
-			},
-			"ncpus": schema.Int64Attribute{
-				Required:    true,
-				Description: "Number of CPUs allocated for this instance.",
-			},
+			"memory": memorySchemaAttribute(),
+			"ncpus":  ncpusSchemaAttribute(),
 			"start_on_create": schema.BoolAttribute{
 				Optional:    true,
 				Computed:    true,
 				Default:     booldefault.StaticBool(true),
 				Description: "Starts the instance on creation",
 			},
-			"attach_to_disks": schema.ListAttribute{
+			"boot_disk": schema.SingleNestedAttribute{
+				Required:    true,
+				Description: "Boot disk for this instance. Immutable: changing any field replaces the instance.",
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
+				Attributes: map[string]schema.Attribute{
+					"disk_id": schema.StringAttribute{
+						Optional:    true,
+						Description: "ID of an existing disk to boot from. Conflicts with initialize_params.",
+						Validators: []validator.String{
+							stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("initialize_params")),
+						},
+					},
+					"device_name": schema.StringAttribute{
+						Required:    true,
+						Description: "Device name of the boot disk as it is attached to the instance.",
+					},
+					"auto_delete": schema.BoolAttribute{
+						Optional:    true,
+						Computed:    true,
+						Default:     booldefault.StaticBool(false),
+						Description: "Recorded in state only: deleting the instance detaches the boot disk and leaves it in place, whatever this is set to.",
+					},
+					"initialize_params": schema.SingleNestedAttribute{
+						Optional:    true,
+						Description: "Creates the boot disk from an image or snapshot as part of instance creation, instead of attaching an existing disk_id.",
+						Attributes: map[string]schema.Attribute{
+							"disk_name": schema.StringAttribute{
+								Required:    true,
+								Description: "Name of the disk to create.",
+							},
+							"size": schema.Int64Attribute{
+								Required:    true,
+								Description: "Size of the disk to create, in bytes.",
+							},
+							"source_image_id": schema.StringAttribute{
+								Optional:    true,
+								Description: "ID of the image to create the disk from. Conflicts with source_snapshot_id.",
+								Validators: []validator.String{
+									stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("source_snapshot_id")),
+								},
+							},
+							"source_snapshot_id": schema.StringAttribute{
+								Optional:    true,
+								Description: "ID of the snapshot to create the disk from. Conflicts with source_image_id.",
+								Validators: []validator.String{
+									stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("source_image_id")),
+								},
+							},
+						},
+					},
+				},
+			},
+			"attached_disk": schema.ListNestedAttribute{
 				Optional:    true,
-				Description: "Disks to be attached to this instance.",
-				ElementType: types.StringType,
+				Description: "Additional disks to attach to this instance.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"disk_id": schema.StringAttribute{
+							Required:    true,
+							Description: "ID of the disk to attach.",
+						},
+						"device_name": schema.StringAttribute{
+							Required:    true,
+							Description: "Device name of the disk as it is attached to the instance.",
+						},
+						"mode": schema.StringAttribute{
+							Optional:    true,
+							Computed:    true,
+							Default:     stringdefault.StaticString("read_write"),
+							Description: "Attachment mode of the disk. One of \"read_write\" or \"read_only\". Recorded in state only: it is not sent to Oxide, which attaches the disk the same way whatever this is set to.",
+						},
+					},
+				},
 			},
-			"external_ips": schema.ListAttribute{
+			"external_ips": schema.ListNestedAttribute{
 				Optional:    true,
-				Description: "External IP addresses provided to this instance. List of IP pools from which to draw addresses.",
-				ElementType: types.StringType,
+				Description: "External IP addresses provided to this instance.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Required:    true,
+							Description: "Type of external IP. One of \"ephemeral\" or \"floating\".",
+							Validators: []validator.String{
+								stringvalidator.OneOf("ephemeral", "floating"),
+							},
+						},
+						"pool_name": schema.StringAttribute{
+							Optional:    true,
+							Description: "IP pool to draw an address from. Required for type \"ephemeral\", ignored otherwise.",
+						},
+						"floating_ip_id": schema.StringAttribute{
+							Optional:    true,
+							Description: "ID of an existing oxide_floating_ip to attach. Required for type \"floating\", ignored otherwise.",
+						},
+						"address": schema.StringAttribute{
+							Computed:    true,
+							Description: "The IP address Oxide assigned.",
+						},
+					},
+				},
+			},
+			"user_data": func() schema.StringAttribute {
+				userData := userDataSchemaAttribute()
+				userData.Validators = append(userData.Validators, stringvalidator.ConflictsWith(path.MatchRoot("metadata_startup_script")))
+				return userData
+			}(),
+			"metadata_startup_script": schema.StringAttribute{
+				Optional: true,
+				Description: "Raw (not Base64-encoded) startup script, such as cloud-init user data, run on boot. " +
+					"Encoded automatically and sent to Oxide as user_data. Conflicts with user_data. Maximum 32 KiB.",
+				Validators: []validator.String{
+					rawUserDataSizeValidator{},
+					stringvalidator.ConflictsWith(path.MatchRoot("user_data")),
+				},
 			},
-			"user_data": schema.StringAttribute{
+			"preserve_run_state": schema.BoolAttribute{
 				Optional: true,
-				Description: "User data for instance initialization systems (such as cloud-init). " +
-					"Must be a Base64-encoded string, as specified in RFC 4648 § 4 (+ and / characters with padding). " +
-					"Maximum 32 KiB unencoded data.",
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+				Description: "Whether to restore the instance to its prior run state (e.g. restart it) after an " +
+					"update that required stopping it. Defaults to true.",
 			},
 			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
 				Create: true,
 				Read:   true,
-				// TODO: Restore once updates are enabled
-				// Update: true,
+				Update: true,
 				Delete: true,
 			}),
 			"id": schema.StringAttribute{
@@ -145,6 +326,434 @@ func (r *instanceResource) Schema(ctx context.Context, _ resource.SchemaRequest,
 	}
 }
 
+// instanceResourceModelV0 is the pre-boot_disk/attached_disk schema, kept
+// only so UpgradeState can decode state written before this change.
+type instanceResourceModelV0 struct {
+	AttachToDisks    types.List     `tfsdk:"attach_to_disks"`
+	Description      types.String   `tfsdk:"description"`
+	ExternalIPs      types.List     `tfsdk:"external_ips"`
+	HostName         types.String   `tfsdk:"host_name"`
+	ID               types.String   `tfsdk:"id"`
+	Memory           types.Int64    `tfsdk:"memory"`
+	Name             types.String   `tfsdk:"name"`
+	NCPUs            types.Int64    `tfsdk:"ncpus"`
+	PreserveRunState types.Bool     `tfsdk:"preserve_run_state"`
+	ProjectID        types.String   `tfsdk:"project_id"`
+	StartOnCreate    types.Bool     `tfsdk:"start_on_create"`
+	TimeCreated      types.String   `tfsdk:"time_created"`
+	TimeModified     types.String   `tfsdk:"time_modified"`
+	Timeouts         timeouts.Value `tfsdk:"timeouts"`
+	UserData         types.String   `tfsdk:"user_data"`
+}
+
+// UpgradeState migrates state written with schema versions 0 and 1
+// directly to the current version (2). For version 0, the list-of-strings
+// attach_to_disks attribute becomes the structured boot_disk/attached_disk
+// blocks: the first disk in attach_to_disks becomes boot_disk; the rest
+// become attached_disk entries. Since attach_to_disks never recorded device
+// names, synthetic ones ("disk0", "disk1", ...) are assigned in list order.
+// For both versions, the list of external_ips pool names is converted by
+// migrateExternalIPPoolNames.
+func (r *instanceResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"project_id": schema.StringAttribute{Required: true},
+					"name":       schema.StringAttribute{Required: true},
+					"description": schema.StringAttribute{
+						Required: true,
+					},
+					"host_name": schema.StringAttribute{Required: true},
+					"memory":    schema.Int64Attribute{Required: true},
+					"ncpus":     schema.Int64Attribute{Required: true},
+					"start_on_create": schema.BoolAttribute{
+						Optional: true,
+						Computed: true,
+					},
+					"attach_to_disks": schema.ListAttribute{
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+					"external_ips": schema.ListAttribute{
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+					"user_data": schema.StringAttribute{Optional: true},
+					"preserve_run_state": schema.BoolAttribute{
+						Optional: true,
+						Computed: true,
+					},
+					"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+						Create: true,
+						Read:   true,
+						Update: true,
+						Delete: true,
+					}),
+					"id":            schema.StringAttribute{Computed: true},
+					"time_created":  schema.StringAttribute{Computed: true},
+					"time_modified": schema.StringAttribute{Computed: true},
+				},
+			},
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var priorState instanceResourceModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &priorState)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				var diskIDs []string
+				resp.Diagnostics.Append(priorState.AttachToDisks.ElementsAs(ctx, &diskIDs, false)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				bootDisk := types.ObjectNull(bootDiskAttrTypes)
+				attachedDisks := []instanceAttachedDiskModel{}
+				if len(diskIDs) > 0 {
+					bootDiskModel := instanceBootDiskModel{
+						DiskID:           types.StringValue(diskIDs[0]),
+						DeviceName:       types.StringValue("disk0"),
+						AutoDelete:       types.BoolValue(false),
+						InitializeParams: types.ObjectNull(bootDiskInitParamsAttrTypes),
+					}
+					obj, diags := types.ObjectValueFrom(ctx, bootDiskAttrTypes, bootDiskModel)
+					resp.Diagnostics.Append(diags...)
+					bootDisk = obj
+
+					for i, diskID := range diskIDs[1:] {
+						attachedDisks = append(attachedDisks, instanceAttachedDiskModel{
+							DiskID:     types.StringValue(diskID),
+							DeviceName: types.StringValue(fmt.Sprintf("disk%d", i+1)),
+							Mode:       types.StringValue("read_write"),
+						})
+					}
+				}
+
+				attachedDiskList, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: attachedDiskAttrTypes}, attachedDisks)
+				resp.Diagnostics.Append(diags...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				externalIPs, diags := migrateExternalIPPoolNames(ctx, priorState.ExternalIPs)
+				resp.Diagnostics.Append(diags...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				upgradedState := instanceResourceModel{
+					AttachedDisk:     attachedDiskList,
+					BootDisk:         bootDisk,
+					Description:      priorState.Description,
+					ExternalIPs:      externalIPs,
+					HostName:         priorState.HostName,
+					ID:               priorState.ID,
+					Memory:           priorState.Memory,
+					Name:             priorState.Name,
+					NCPUs:            priorState.NCPUs,
+					PreserveRunState: priorState.PreserveRunState,
+					ProjectID:        priorState.ProjectID,
+					StartOnCreate:    priorState.StartOnCreate,
+					TimeCreated:      priorState.TimeCreated,
+					TimeModified:     priorState.TimeModified,
+					Timeouts:         priorState.Timeouts,
+					UserData:         priorState.UserData,
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, upgradedState)...)
+			},
+		},
+		1: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"project_id":  schema.StringAttribute{Required: true},
+					"name":        schema.StringAttribute{Required: true},
+					"description": schema.StringAttribute{Required: true},
+					"host_name":   schema.StringAttribute{Required: true},
+					"memory":      schema.Int64Attribute{Required: true},
+					"ncpus":       schema.Int64Attribute{Required: true},
+					"start_on_create": schema.BoolAttribute{
+						Optional: true,
+						Computed: true,
+					},
+					"boot_disk": schema.SingleNestedAttribute{
+						Required: true,
+						Attributes: map[string]schema.Attribute{
+							"disk_id":     schema.StringAttribute{Optional: true},
+							"device_name": schema.StringAttribute{Required: true},
+							"auto_delete": schema.BoolAttribute{Optional: true, Computed: true},
+							"initialize_params": schema.SingleNestedAttribute{
+								Optional: true,
+								Attributes: map[string]schema.Attribute{
+									"disk_name":          schema.StringAttribute{Required: true},
+									"size":               schema.Int64Attribute{Required: true},
+									"source_image_id":    schema.StringAttribute{Optional: true},
+									"source_snapshot_id": schema.StringAttribute{Optional: true},
+								},
+							},
+						},
+					},
+					"attached_disk": schema.ListNestedAttribute{
+						Optional: true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"disk_id":     schema.StringAttribute{Required: true},
+								"device_name": schema.StringAttribute{Required: true},
+								"mode":        schema.StringAttribute{Optional: true, Computed: true},
+							},
+						},
+					},
+					"external_ips": schema.ListAttribute{
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+					"user_data": schema.StringAttribute{Optional: true},
+					"preserve_run_state": schema.BoolAttribute{
+						Optional: true,
+						Computed: true,
+					},
+					"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+						Create: true,
+						Read:   true,
+						Update: true,
+						Delete: true,
+					}),
+					"id":            schema.StringAttribute{Computed: true},
+					"time_created":  schema.StringAttribute{Computed: true},
+					"time_modified": schema.StringAttribute{Computed: true},
+				},
+			},
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var priorState instanceResourceModelV1
+				resp.Diagnostics.Append(req.State.Get(ctx, &priorState)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				externalIPs, diags := migrateExternalIPPoolNames(ctx, priorState.ExternalIPs)
+				resp.Diagnostics.Append(diags...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				upgradedState := instanceResourceModel{
+					AttachedDisk:     priorState.AttachedDisk,
+					BootDisk:         priorState.BootDisk,
+					Description:      priorState.Description,
+					ExternalIPs:      externalIPs,
+					HostName:         priorState.HostName,
+					ID:               priorState.ID,
+					Memory:           priorState.Memory,
+					Name:             priorState.Name,
+					NCPUs:            priorState.NCPUs,
+					PreserveRunState: priorState.PreserveRunState,
+					ProjectID:        priorState.ProjectID,
+					StartOnCreate:    priorState.StartOnCreate,
+					TimeCreated:      priorState.TimeCreated,
+					TimeModified:     priorState.TimeModified,
+					Timeouts:         priorState.Timeouts,
+					UserData:         priorState.UserData,
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, upgradedState)...)
+			},
+		},
+	}
+}
+
+// instanceResourceModelV1 is the schema-version-1 shape, after
+// boot_disk/attached_disk replaced attach_to_disks but before external_ips
+// became a structured block. Kept only so UpgradeState can decode state
+// written by that version.
+type instanceResourceModelV1 struct {
+	AttachedDisk     types.List     `tfsdk:"attached_disk"`
+	BootDisk         types.Object   `tfsdk:"boot_disk"`
+	Description      types.String   `tfsdk:"description"`
+	ExternalIPs      types.List     `tfsdk:"external_ips"`
+	HostName         types.String   `tfsdk:"host_name"`
+	ID               types.String   `tfsdk:"id"`
+	Memory           types.Int64    `tfsdk:"memory"`
+	Name             types.String   `tfsdk:"name"`
+	NCPUs            types.Int64    `tfsdk:"ncpus"`
+	PreserveRunState types.Bool     `tfsdk:"preserve_run_state"`
+	ProjectID        types.String   `tfsdk:"project_id"`
+	StartOnCreate    types.Bool     `tfsdk:"start_on_create"`
+	TimeCreated      types.String   `tfsdk:"time_created"`
+	TimeModified     types.String   `tfsdk:"time_modified"`
+	Timeouts         timeouts.Value `tfsdk:"timeouts"`
+	UserData         types.String   `tfsdk:"user_data"`
+}
+
+// migrateExternalIPPoolNames converts the pre-version-2 external_ips shape
+// (a bare list of pool names) into the structured ephemeral/floating list,
+// treating every entry as an ephemeral IP drawn from that pool since that
+// was the only type earlier versions supported.
+func migrateExternalIPPoolNames(ctx context.Context, poolNames types.List) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var names []string
+	diags.Append(poolNames.ElementsAs(ctx, &names, false)...)
+	if diags.HasError() {
+		return types.ListNull(types.ObjectType{AttrTypes: externalIPAttrTypes}), diags
+	}
+
+	externalIPs := make([]instanceExternalIPModel, len(names))
+	for i, name := range names {
+		externalIPs[i] = instanceExternalIPModel{
+			Type:         types.StringValue("ephemeral"),
+			PoolName:     types.StringValue(name),
+			FloatingIPID: types.StringNull(),
+			Address:      types.StringNull(),
+		}
+	}
+
+	list, d := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: externalIPAttrTypes}, externalIPs)
+	diags.Append(d...)
+	return list, diags
+}
+
+// instanceBootDiskAttachment builds the InstanceDiskAttachment for a
+// boot_disk block: attaching an existing disk if disk_id was given, or
+// creating a new one from initialize_params otherwise.
+func instanceBootDiskAttachment(ctx context.Context, bootDisk instanceBootDiskModel) (oxideSDK.InstanceDiskAttachment, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if bootDisk.InitializeParams.IsNull() {
+		return oxideSDK.InstanceDiskAttachment{
+			Name: oxideSDK.Name(bootDisk.DiskID.ValueString()),
+			Type: oxideSDK.InstanceDiskAttachmentTypeAttach,
+		}, diags
+	}
+
+	var initParams instanceBootDiskInitializeParamsModel
+	diags.Append(bootDisk.InitializeParams.As(ctx, &initParams, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return oxideSDK.InstanceDiskAttachment{}, diags
+	}
+
+	diskSource := oxideSDK.DiskSource{Type: oxideSDK.DiskSourceTypeBlank}
+	switch {
+	case !initParams.SourceImageID.IsNull():
+		diskSource = oxideSDK.DiskSource{
+			Type:    oxideSDK.DiskSourceTypeImage,
+			ImageId: initParams.SourceImageID.ValueString(),
+		}
+	case !initParams.SourceSnapshotID.IsNull():
+		diskSource = oxideSDK.DiskSource{
+			Type:       oxideSDK.DiskSourceTypeSnapshot,
+			SnapshotId: initParams.SourceSnapshotID.ValueString(),
+		}
+	}
+
+	return oxideSDK.InstanceDiskAttachment{
+		Type:        oxideSDK.InstanceDiskAttachmentTypeCreate,
+		Name:        oxideSDK.Name(initParams.DiskName.ValueString()),
+		Description: fmt.Sprintf("Boot disk %q, created by Terraform.", initParams.DiskName.ValueString()),
+		Size:        oxideSDK.ByteCount(initParams.Size.ValueInt64()),
+		DiskSource:  diskSource,
+	}, diags
+}
+
+// instanceExternalIPAttachBody builds the ExternalIpCreate body used to
+// attach a single external_ips entry to an instance: an ephemeral IP drawn
+// from pool_name, or an existing floating IP identified by floating_ip_id.
+func instanceExternalIPAttachBody(ip instanceExternalIPModel) oxideSDK.ExternalIpCreate {
+	if ip.Type.ValueString() == "floating" {
+		return oxideSDK.ExternalIpCreate{
+			Type:         oxideSDK.ExternalIpCreateTypeFloating,
+			FloatingIpId: ip.FloatingIPID.ValueString(),
+		}
+	}
+	return oxideSDK.ExternalIpCreate{
+		Type:     oxideSDK.ExternalIpCreateTypeEphemeral,
+		PoolName: oxideSDK.Name(ip.PoolName.ValueString()),
+	}
+}
+
+// instanceExternalIPDetachBody builds the ExternalIpDetach body for a
+// single external_ips entry, mirroring instanceExternalIPAttachBody.
+func instanceExternalIPDetachBody(ip instanceExternalIPModel) oxideSDK.ExternalIpDetach {
+	if ip.Type.ValueString() == "floating" {
+		return oxideSDK.ExternalIpDetach{
+			Type:       oxideSDK.ExternalIpDetachTypeFloating,
+			FloatingIp: oxideSDK.NameOrId(ip.FloatingIPID.ValueString()),
+		}
+	}
+	return oxideSDK.ExternalIpDetach{Type: oxideSDK.ExternalIpDetachTypeEphemeral}
+}
+
+// instanceExternalIPKey returns a key identifying an external_ips entry
+// that is stable across plan/state so Update can diff the two lists:
+// ephemeral entries are keyed by pool, floating entries by the floating
+// IP's id.
+func instanceExternalIPKey(ip instanceExternalIPModel) string {
+	if ip.Type.ValueString() == "floating" {
+		return "floating:" + ip.FloatingIPID.ValueString()
+	}
+	return "ephemeral:" + ip.PoolName.ValueString()
+}
+
+// instanceUserData returns the Base64-encoded string to send to Oxide as
+// InstanceCreate's user_data: metadata_startup_script, encoded, if the user
+// set it, otherwise user_data as-is (it's expected to already be encoded).
+func instanceUserData(plan instanceResourceModel) string {
+	if !plan.MetadataStartupScript.IsNull() {
+		return base64.StdEncoding.EncodeToString([]byte(plan.MetadataStartupScript.ValueString()))
+	}
+	return plan.UserData.ValueString()
+}
+
+// instanceExternalIPsFromAPI fetches instanceID's current external IPs and
+// converts them to the external_ips schema shape. The Oxide API doesn't
+// report which pool an already-allocated ephemeral IP was drawn from, so
+// pool_name for an ephemeral entry is recovered from prior (the
+// corresponding state or plan list, matched by address, since the address
+// doesn't change for as long as the IP stays attached) instead of being
+// reconstructed from the API response. Without this, pool_name would be
+// nulled out on every Read even though config still sets it, producing a
+// permanent diff and -- because updateInstanceExternalIPs keys ephemeral
+// entries by pool_name -- churning the assigned address on every apply.
+func instanceExternalIPsFromAPI(client *oxideSDK.Client, instanceID oxideSDK.NameOrId, prior []instanceExternalIPModel) ([]instanceExternalIPModel, error) {
+	externalIPs, err := client.InstanceExternalIpList(oxideSDK.InstanceExternalIpListParams{Instance: instanceID})
+	if err != nil {
+		return nil, err
+	}
+
+	priorByAddress := make(map[string]instanceExternalIPModel, len(prior))
+	for _, ip := range prior {
+		if !ip.Address.IsNull() {
+			priorByAddress[ip.Address.ValueString()] = ip
+		}
+	}
+
+	models := make([]instanceExternalIPModel, 0, len(externalIPs.Items))
+	for _, ip := range externalIPs.Items {
+		switch ip.Kind {
+		case oxideSDK.ExternalIpKindFloating:
+			models = append(models, instanceExternalIPModel{
+				Type:         types.StringValue("floating"),
+				PoolName:     types.StringNull(),
+				FloatingIPID: types.StringValue(ip.Id),
+				Address:      types.StringValue(ip.Ip),
+			})
+		default:
+			poolName := types.StringNull()
+			if priorIP, ok := priorByAddress[ip.Ip]; ok && priorIP.Type.ValueString() == "ephemeral" {
+				poolName = priorIP.PoolName
+			}
+			models = append(models, instanceExternalIPModel{
+				Type:         types.StringValue("ephemeral"),
+				PoolName:     poolName,
+				FloatingIPID: types.StringNull(),
+				Address:      types.StringValue(ip.Ip),
+			})
+		}
+	}
+
+	return models, nil
+}
+
 // Create creates the resource and sets the initial Terraform state.
 func (r *instanceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan instanceResourceModel
@@ -181,64 +790,122 @@ func (r *instanceResource) Create(ctx context.Context, req resource.CreateReques
 			NetworkInterfaces: oxideSDK.InstanceNetworkInterfaceAttachment{
 				Type: oxideSDK.InstanceNetworkInterfaceAttachmentTypeNone,
 			},
-			UserData: plan.UserData.ValueString(),
+			UserData: instanceUserData(plan),
 		},
 	}
 
-	// TODO: Perhaps it makes sense to attach after the resource is created instead of
-	// making it part of the create body.
-	var diskAttachements = []oxideSDK.InstanceDiskAttachment{}
-	for _, disk := range plan.AttachToDisks.Elements() {
-		diskName, err := strconv.Unquote(disk.String())
+	var bootDisk instanceBootDiskModel
+	resp.Diagnostics.Append(plan.BootDisk.As(ctx, &bootDisk, basetypes.ObjectAsOptions{})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bootAttachment, diags := instanceBootDiskAttachment(ctx, bootDisk)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var attachedDisks []instanceAttachedDiskModel
+	resp.Diagnostics.Append(plan.AttachedDisk.ElementsAs(ctx, &attachedDisks, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diskAttachments := []oxideSDK.InstanceDiskAttachment{bootAttachment}
+	for _, disk := range attachedDisks {
+		diskAttachments = append(diskAttachments, oxideSDK.InstanceDiskAttachment{
+			Name: oxideSDK.Name(disk.DiskID.ValueString()),
+			Type: oxideSDK.InstanceDiskAttachmentTypeAttach,
+		})
+	}
+	params.Body.Disks = diskAttachments
+
+	instance, err := r.client.InstanceCreate(params)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating instance",
+			"API error: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("created instance with ID: %v", instance.Id), map[string]any{"success": true})
+
+	// For the initialize_params path, InstanceCreate only took a disk name
+	// to create from, not an id, so disk_id still needs to be read back by
+	// looking up the newly created disk by that name. Without this,
+	// boot_disk.disk_id is left null and Read's matching falls back to
+	// guessing instead of this known-good id.
+	if !bootDisk.InitializeParams.IsNull() {
+		var initParams instanceBootDiskInitializeParamsModel
+		resp.Diagnostics.Append(bootDisk.InitializeParams.As(ctx, &initParams, basetypes.ObjectAsOptions{})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		bootDisks, err := r.client.InstanceDiskList(oxideSDK.InstanceDiskListParams{
+			Instance: oxideSDK.NameOrId(instance.Id),
+			Limit:    1000000000,
+		})
 		if err != nil {
 			resp.Diagnostics.AddError(
-				"Error attaching instance to disk",
-				"IP pool name parse error: "+err.Error(),
+				"Unable to list attached disks:",
+				"API error: "+err.Error(),
 			)
 			return
 		}
-		ds := oxideSDK.InstanceDiskAttachment{
-			Name: oxideSDK.Name(diskName),
-			// TODO: For now we are only attaching. Verify if it makes sense to create
-			// as well. Probably not, there would be no way to delete that disk via
-			// TF
-			Type: oxideSDK.InstanceDiskAttachmentTypeAttach,
+
+		for _, disk := range bootDisks.Items {
+			if string(disk.Name) == initParams.DiskName.ValueString() {
+				bootDisk.DiskID = types.StringValue(disk.Id)
+				break
+			}
 		}
 
-		diskAttachements = append(diskAttachements, ds)
+		bootDiskObj, diags := types.ObjectValueFrom(ctx, bootDiskAttrTypes, bootDisk)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		plan.BootDisk = bootDiskObj
+	}
+
+	// External IPs are attached after the instance exists rather than as
+	// part of the create body: attaching an existing oxide_floating_ip
+	// needs the instance's id, and doing both kinds the same way keeps this
+	// in sync with updateInstanceExternalIPs.
+	var externalIPs []instanceExternalIPModel
+	resp.Diagnostics.Append(plan.ExternalIPs.ElementsAs(ctx, &externalIPs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	params.Body.Disks = diskAttachements
 
-	var externalIPs = []oxideSDK.ExternalIpCreate{}
-	for _, ip := range plan.ExternalIPs.Elements() {
-		poolName, err := strconv.Unquote(ip.String())
+	attachedExternalIPs := make([]instanceExternalIPModel, 0, len(externalIPs))
+	for _, ip := range externalIPs {
+		body := instanceExternalIPAttachBody(ip)
+		attached, err := r.client.InstanceExternalIpAttach(oxideSDK.InstanceExternalIpAttachParams{
+			Instance: oxideSDK.NameOrId(instance.Id),
+			Body:     &body,
+		})
 		if err != nil {
 			resp.Diagnostics.AddError(
-				"Error creating external IP addresses",
-				"IP pool name parse error: "+err.Error(),
+				"Unable to attach external IP:",
+				"API error: "+err.Error(),
 			)
 			return
 		}
-		eIP := oxideSDK.ExternalIpCreate{
-			PoolName: oxideSDK.Name(poolName),
-			// TODO: Implement other types when these are supported.
-			Type: oxideSDK.ExternalIpCreateTypeEphemeral,
-		}
-
-		externalIPs = append(externalIPs, eIP)
+		ip.Address = types.StringValue(attached.Ip)
+		attachedExternalIPs = append(attachedExternalIPs, ip)
+		tflog.Trace(ctx, fmt.Sprintf("attached external IP to instance with ID: %v", instance.Id), map[string]any{"success": true})
 	}
-	params.Body.ExternalIps = externalIPs
 
-	instance, err := r.client.InstanceCreate(params)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error creating instance",
-			"API error: "+err.Error(),
-		)
+	externalIPList, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: externalIPAttrTypes}, attachedExternalIPs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-
-	tflog.Trace(ctx, fmt.Sprintf("created instance with ID: %v", instance.Id), map[string]any{"success": true})
+	plan.ExternalIPs = externalIPList
 
 	// Map response body to schema and populate Computed attribute values
 	plan.ID = types.StringValue(instance.Id)
@@ -293,8 +960,146 @@ func (r *instanceResource) Read(ctx context.Context, req resource.ReadRequest, r
 	state.TimeCreated = types.StringValue(instance.TimeCreated.String())
 	state.TimeModified = types.StringValue(instance.TimeCreated.String())
 
-	//state.AttachToDisks = TODO
-	//state.ExternalIPs = TODO
+	// Reflect whatever Oxide actually has stored back into whichever of
+	// user_data/metadata_startup_script the user set, so the two never
+	// both go out of sync with the server and neither ever perpetually
+	// diffs against the other's empty value.
+	if instance.UserData != "" {
+		decoded, err := base64.StdEncoding.DecodeString(instance.UserData)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to decode user data:",
+				"Base64 decode error: "+err.Error(),
+			)
+			return
+		}
+
+		if !state.MetadataStartupScript.IsNull() {
+			state.MetadataStartupScript = types.StringValue(string(decoded))
+		} else {
+			state.UserData = types.StringValue(instance.UserData)
+		}
+	}
+
+	disks, err := r.client.InstanceDiskList(oxideSDK.InstanceDiskListParams{
+		Instance: oxideSDK.NameOrId(state.ID.ValueString()),
+		Limit:    1000000000,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to list attached disks:",
+			"API error: "+err.Error(),
+		)
+		return
+	}
+
+	// The Oxide API has no notion of a "boot disk" on a disk listing, so
+	// the disk matching the id we already have recorded as boot_disk is
+	// treated as the boot disk and every other disk becomes an
+	// attached_disk entry. If we don't have an id yet, device_name is
+	// matched against the disk's own name instead; list order is not
+	// guaranteed, so only a bare import -- where neither is recorded --
+	// falls back to the first disk returned. device_name, auto_delete, and
+	// initialize_params aren't reported by the disk listing at all, so
+	// they're carried over from the prior state; only when state has none
+	// (as after an import) is device_name taken from the disk's name and
+	// auto_delete defaulted to false -- boot_disk is
+	// RequiresReplace, so overwriting them here with anything other than
+	// what's in state would force a destroy/recreate on every plan.
+	var priorBootDisk instanceBootDiskModel
+	if !state.BootDisk.IsNull() {
+		resp.Diagnostics.Append(state.BootDisk.As(ctx, &priorBootDisk, basetypes.ObjectAsOptions{})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	priorAttachedDisks := make(map[string]instanceAttachedDiskModel)
+	var priorAttachedDiskList []instanceAttachedDiskModel
+	resp.Diagnostics.Append(state.AttachedDisk.ElementsAs(ctx, &priorAttachedDiskList, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for _, disk := range priorAttachedDiskList {
+		priorAttachedDisks[disk.DiskID.ValueString()] = disk
+	}
+
+	var bootDisk *instanceBootDiskModel
+	attachedDisks := make([]instanceAttachedDiskModel, 0, len(disks.Items))
+	for _, disk := range disks.Items {
+		var matchesBootDisk bool
+		switch {
+		case !priorBootDisk.DiskID.IsNull():
+			matchesBootDisk = disk.Id == priorBootDisk.DiskID.ValueString()
+		case !priorBootDisk.DeviceName.IsNull():
+			matchesBootDisk = string(disk.Name) == priorBootDisk.DeviceName.ValueString()
+		default:
+			matchesBootDisk = true
+		}
+
+		if bootDisk == nil && matchesBootDisk {
+			bootDisk = &instanceBootDiskModel{
+				DiskID:           types.StringValue(disk.Id),
+				DeviceName:       priorBootDisk.DeviceName,
+				AutoDelete:       priorBootDisk.AutoDelete,
+				InitializeParams: priorBootDisk.InitializeParams,
+			}
+			if bootDisk.DeviceName.IsNull() {
+				bootDisk.DeviceName = types.StringValue(string(disk.Name))
+			}
+			if bootDisk.AutoDelete.IsNull() {
+				bootDisk.AutoDelete = types.BoolValue(false)
+			}
+			if bootDisk.InitializeParams.IsNull() {
+				bootDisk.InitializeParams = types.ObjectNull(bootDiskInitParamsAttrTypes)
+			}
+			continue
+		}
+
+		attached := instanceAttachedDiskModel{
+			DiskID:     types.StringValue(disk.Id),
+			DeviceName: types.StringValue(string(disk.Name)),
+			Mode:       types.StringValue("read_write"),
+		}
+		if prior, ok := priorAttachedDisks[disk.Id]; ok {
+			attached.DeviceName = prior.DeviceName
+			attached.Mode = prior.Mode
+		}
+		attachedDisks = append(attachedDisks, attached)
+	}
+
+	if bootDisk != nil {
+		bootDiskObj, diags := types.ObjectValueFrom(ctx, bootDiskAttrTypes, bootDisk)
+		resp.Diagnostics.Append(diags...)
+		state.BootDisk = bootDiskObj
+	}
+
+	attachedDiskList, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: attachedDiskAttrTypes}, attachedDisks)
+	resp.Diagnostics.Append(diags...)
+	state.AttachedDisk = attachedDiskList
+
+	var priorExternalIPs []instanceExternalIPModel
+	resp.Diagnostics.Append(state.ExternalIPs.ElementsAs(ctx, &priorExternalIPs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	externalIPModels, err := instanceExternalIPsFromAPI(r.client, oxideSDK.NameOrId(state.ID.ValueString()), priorExternalIPs)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to list external IPs:",
+			"API error: "+err.Error(),
+		)
+		return
+	}
+
+	externalIPList, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: externalIPAttrTypes}, externalIPModels)
+	resp.Diagnostics.Append(diags...)
+	state.ExternalIPs = externalIPList
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
@@ -304,10 +1109,314 @@ func (r *instanceResource) Read(ctx context.Context, req resource.ReadRequest, r
 }
 
 // Update updates the resource and sets the updated Terraform state on success.
+//
+// Memory, ncpus, host name, and attached_disk changes all require the
+// instance to be stopped before the Oxide API will accept them (disk
+// attach/detach included: Oxide rejects both on a running instance), so
+// Update reconciles those fields via a stop-modify-start cycle: it stops
+// the instance if it is running and a stop-requiring field changed, applies
+// the hardware/disk/external IP diffs, and then restarts the instance if it
+// was running beforehand and preserve_run_state is true.
 func (r *instanceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	resp.Diagnostics.AddError(
-		"Error updating instance",
-		"the oxide API currently does not support updating instances")
+	var plan, state instanceResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultTimeout())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	instanceID := oxideSDK.NameOrId(state.ID.ValueString())
+
+	instance, err := r.client.InstanceView(oxideSDK.InstanceViewParams{Instance: instanceID})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read instance:",
+			"API error: "+err.Error(),
+		)
+		return
+	}
+	wasRunning := instance.RunState == oxideSDK.InstanceStateRunning
+
+	requiresStop := !plan.Memory.Equal(state.Memory) ||
+		!plan.NCPUs.Equal(state.NCPUs) ||
+		!plan.HostName.Equal(state.HostName) ||
+		!plan.AttachedDisk.Equal(state.AttachedDisk)
+
+	if wasRunning && requiresStop {
+		if _, err := r.client.InstanceStop(oxideSDK.InstanceStopParams{Instance: instanceID}); err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to stop instance:",
+				"API error: "+err.Error(),
+			)
+			return
+		}
+
+		stopWaiter := wait.Waiter{
+			Pending:        []string{string(oxideSDK.InstanceStateRunning), string(oxideSDK.InstanceStateStopping)},
+			Target:         []string{string(oxideSDK.InstanceStateStopped)},
+			Refresh:        instanceRefresh(r.client, instanceID),
+			Timeout:        updateTimeout,
+			MinTimeout:     5 * time.Second,
+			PollInterval:   time.Second,
+			NotFoundChecks: 1,
+		}
+		if _, err := stopWaiter.WaitForStateContext(ctx); err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to stop instance:",
+				"API error: "+err.Error(),
+			)
+			return
+		}
+		tflog.Trace(ctx, fmt.Sprintf("stopped instance with ID: %v", state.ID.ValueString()), map[string]any{"success": true})
+	}
+
+	if requiresStop || !plan.Description.Equal(state.Description) {
+		_, err := r.client.InstanceUpdate(oxideSDK.InstanceUpdateParams{
+			Instance: instanceID,
+			Body: &oxideSDK.InstanceUpdate{
+				Description: plan.Description.ValueString(),
+				Hostname:    plan.HostName.ValueString(),
+				Memory:      oxideSDK.ByteCount(plan.Memory.ValueInt64()),
+				Ncpus:       oxideSDK.InstanceCpuCount(plan.NCPUs.ValueInt64()),
+			},
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to update instance:",
+				"API error: "+err.Error(),
+			)
+			return
+		}
+		tflog.Trace(ctx, fmt.Sprintf("updated instance with ID: %v", state.ID.ValueString()), map[string]any{"success": true})
+	}
+
+	if err := r.updateInstanceDisks(ctx, instanceID, state, plan, resp); err != nil {
+		return
+	}
+
+	if err := r.updateInstanceExternalIPs(ctx, instanceID, state, plan, resp); err != nil {
+		return
+	}
+
+	var configuredExternalIPs []instanceExternalIPModel
+	resp.Diagnostics.Append(plan.ExternalIPs.ElementsAs(ctx, &configuredExternalIPs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	externalIPModels, err := instanceExternalIPsFromAPI(r.client, instanceID, configuredExternalIPs)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to list external IPs:",
+			"API error: "+err.Error(),
+		)
+		return
+	}
+	externalIPList, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: externalIPAttrTypes}, externalIPModels)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.ExternalIPs = externalIPList
+
+	if wasRunning && plan.PreserveRunState.ValueBool() && requiresStop {
+		if _, err := r.client.InstanceStart(oxideSDK.InstanceStartParams{Instance: instanceID}); err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to start instance:",
+				"API error: "+err.Error(),
+			)
+			return
+		}
+
+		startWaiter := wait.Waiter{
+			Pending:        []string{string(oxideSDK.InstanceStateStopped), string(oxideSDK.InstanceStateStarting)},
+			Target:         []string{string(oxideSDK.InstanceStateRunning)},
+			Refresh:        instanceRefresh(r.client, instanceID),
+			Timeout:        updateTimeout,
+			MinTimeout:     5 * time.Second,
+			PollInterval:   time.Second,
+			NotFoundChecks: 0,
+		}
+		if _, err := startWaiter.WaitForStateContext(ctx); err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to start instance:",
+				"API error: "+err.Error(),
+			)
+			return
+		}
+		tflog.Trace(ctx, fmt.Sprintf("started instance with ID: %v", state.ID.ValueString()), map[string]any{"success": true})
+	}
+
+	instance, err = r.client.InstanceView(oxideSDK.InstanceViewParams{Instance: instanceID})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to read instance:",
+			"API error: "+err.Error(),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(instance.Id)
+	plan.TimeCreated = types.StringValue(instance.TimeCreated.String())
+	plan.TimeModified = types.StringValue(instance.TimeModified.String())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// updateInstanceDisks reconciles the attached_disk diff between state and
+// plan, detaching disks that were removed and attaching disks that were
+// added. boot_disk is immutable (RequiresReplace) so it is never part of
+// this diff. It reports any error via resp.Diagnostics and returns it so
+// the caller can bail out of Update.
+func (r *instanceResource) updateInstanceDisks(ctx context.Context, instanceID oxideSDK.NameOrId, state, plan instanceResourceModel, resp *resource.UpdateResponse) error {
+	var stateDisks, planDisks []instanceAttachedDiskModel
+	resp.Diagnostics.Append(state.AttachedDisk.ElementsAs(ctx, &stateDisks, false)...)
+	resp.Diagnostics.Append(plan.AttachedDisk.ElementsAs(ctx, &planDisks, false)...)
+	if resp.Diagnostics.HasError() {
+		return fmt.Errorf("unable to parse attached_disk")
+	}
+
+	stateDiskIDs := make([]string, len(stateDisks))
+	for i, disk := range stateDisks {
+		stateDiskIDs[i] = disk.DiskID.ValueString()
+	}
+	planDiskIDs := make([]string, len(planDisks))
+	for i, disk := range planDisks {
+		planDiskIDs[i] = disk.DiskID.ValueString()
+	}
+
+	toAttach, toDetach := diffStringSets(stateDiskIDs, planDiskIDs)
+
+	for _, disk := range toDetach {
+		_, err := r.client.InstanceDiskDetach(oxideSDK.InstanceDiskDetachParams{
+			Instance: instanceID,
+			Body:     &oxideSDK.DiskPath{Disk: oxideSDK.NameOrId(disk)},
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to detach disk:",
+				"API error: "+err.Error(),
+			)
+			return err
+		}
+		tflog.Trace(ctx, fmt.Sprintf("detached disk %q from instance with ID: %v", disk, instanceID), map[string]any{"success": true})
+	}
+
+	for _, disk := range toAttach {
+		_, err := r.client.InstanceDiskAttach(oxideSDK.InstanceDiskAttachParams{
+			Instance: instanceID,
+			Body:     &oxideSDK.DiskPath{Disk: oxideSDK.NameOrId(disk)},
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to attach disk:",
+				"API error: "+err.Error(),
+			)
+			return err
+		}
+		tflog.Trace(ctx, fmt.Sprintf("attached disk %q to instance with ID: %v", disk, instanceID), map[string]any{"success": true})
+	}
+
+	return nil
+}
+
+// updateInstanceExternalIPs reconciles the external_ips diff between state
+// and plan, detaching entries that were removed and attaching entries that
+// were added. Entries are matched between state and plan by
+// instanceExternalIPKey, since neither an ephemeral pool name nor a
+// floating IP id can change in place -- a changed entry is a detach of the
+// old one plus an attach of the new one. It reports any error via
+// resp.Diagnostics and returns it so the caller can bail out of Update.
+func (r *instanceResource) updateInstanceExternalIPs(ctx context.Context, instanceID oxideSDK.NameOrId, state, plan instanceResourceModel, resp *resource.UpdateResponse) error {
+	var stateIPs, planIPs []instanceExternalIPModel
+	resp.Diagnostics.Append(state.ExternalIPs.ElementsAs(ctx, &stateIPs, false)...)
+	resp.Diagnostics.Append(plan.ExternalIPs.ElementsAs(ctx, &planIPs, false)...)
+	if resp.Diagnostics.HasError() {
+		return fmt.Errorf("unable to parse external_ips")
+	}
+
+	stateByKey := make(map[string]instanceExternalIPModel, len(stateIPs))
+	for _, ip := range stateIPs {
+		stateByKey[instanceExternalIPKey(ip)] = ip
+	}
+	planByKey := make(map[string]instanceExternalIPModel, len(planIPs))
+	for _, ip := range planIPs {
+		planByKey[instanceExternalIPKey(ip)] = ip
+	}
+
+	for key, ip := range stateByKey {
+		if _, ok := planByKey[key]; ok {
+			continue
+		}
+		body := instanceExternalIPDetachBody(ip)
+		_, err := r.client.InstanceExternalIpDetach(oxideSDK.InstanceExternalIpDetachParams{
+			Instance: instanceID,
+			Body:     &body,
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to detach external IP:",
+				"API error: "+err.Error(),
+			)
+			return err
+		}
+		tflog.Trace(ctx, fmt.Sprintf("detached external IP %q from instance with ID: %v", key, instanceID), map[string]any{"success": true})
+	}
+
+	for key, ip := range planByKey {
+		if _, ok := stateByKey[key]; ok {
+			continue
+		}
+		body := instanceExternalIPAttachBody(ip)
+		_, err := r.client.InstanceExternalIpAttach(oxideSDK.InstanceExternalIpAttachParams{
+			Instance: instanceID,
+			Body:     &body,
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to attach external IP:",
+				"API error: "+err.Error(),
+			)
+			return err
+		}
+		tflog.Trace(ctx, fmt.Sprintf("attached external IP %q to instance with ID: %v", key, instanceID), map[string]any{"success": true})
+	}
+
+	return nil
+}
+
+// diffStringSets compares the old and new values of an unordered string list
+// attribute and reports which elements were added and which were removed.
+func diffStringSets(old, new []string) (added, removed []string) {
+	oldSet := make(map[string]bool, len(old))
+	for _, s := range old {
+		oldSet[s] = true
+	}
+
+	newSet := make(map[string]bool, len(new))
+	for _, s := range new {
+		newSet[s] = true
+		if !oldSet[s] {
+			added = append(added, s)
+		}
+	}
+
+	for _, s := range old {
+		if !newSet[s] {
+			removed = append(removed, s)
+		}
+	}
+
+	return added, removed
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -325,7 +1434,7 @@ func (r *instanceResource) Delete(ctx context.Context, req resource.DeleteReques
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	_, cancel := context.WithTimeout(ctx, deleteTimeout)
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
 	defer cancel()
 
 	// Detach any associated disks
@@ -377,13 +1486,19 @@ func (r *instanceResource) Delete(ctx context.Context, req resource.DeleteReques
 		}
 	}
 
-	ch := make(chan error)
-	go waitForStoppedInstance(r.client, oxideSDK.NameOrId(state.ID.ValueString()), ch)
-	e := <-ch
-	if !is404(e) {
+	stopWaiter := wait.Waiter{
+		Pending:        []string{string(oxideSDK.InstanceStateRunning), string(oxideSDK.InstanceStateStopping)},
+		Target:         []string{string(oxideSDK.InstanceStateStopped)},
+		Refresh:        instanceRefresh(r.client, oxideSDK.NameOrId(state.ID.ValueString())),
+		Timeout:        deleteTimeout,
+		MinTimeout:     5 * time.Second,
+		PollInterval:   time.Second,
+		NotFoundChecks: 3,
+	}
+	if _, err := stopWaiter.WaitForStateContext(ctx); err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to stop instance:",
-			"API error: "+e.Error(),
+			"API error: "+err.Error(),
 		)
 		return
 	}
@@ -403,19 +1518,20 @@ func (r *instanceResource) Delete(ctx context.Context, req resource.DeleteReques
 	tflog.Trace(ctx, fmt.Sprintf("deleted instance with ID: %v", state.ID.ValueString()), map[string]any{"success": true})
 }
 
-func waitForStoppedInstance(client *oxideSDK.Client, instanceID oxideSDK.NameOrId, ch chan error) {
-	for {
-		params := oxideSDK.InstanceViewParams{Instance: instanceID}
-		resp, err := client.InstanceView(params)
+// instanceRefresh returns a wait.RefreshFunc that reports the current run
+// state of instanceID, suitable for driving a wait.Waiter through instance
+// start/stop transitions. A 404 is reported as "not found" rather than an
+// error so that callers waiting on deletion can tolerate it via
+// NotFoundChecks.
+func instanceRefresh(client *oxideSDK.Client, instanceID oxideSDK.NameOrId) wait.RefreshFunc {
+	return func() (any, string, error) {
+		instance, err := client.InstanceView(oxideSDK.InstanceViewParams{Instance: instanceID})
 		if err != nil {
-			ch <- err
-		}
-		if resp.RunState == oxideSDK.InstanceStateStopped {
-			break
+			if is404(err) {
+				return nil, "", nil
+			}
+			return nil, "", err
 		}
-		// Suggested alternatives suggested by linter are not fit for purpose
-		//lintignore:R018
-		time.Sleep(time.Second)
+		return instance, string(instance.RunState), nil
 	}
-	ch <- nil
 }
@@ -0,0 +1,550 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package oxide
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	oxideSDK "github.com/oxidecomputer/oxide.go/oxide"
+
+	"github.com/oxidecomputer/terraform-provider-oxide/internal/wait"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = (*instanceGroupResource)(nil)
+	_ resource.ResourceWithConfigure = (*instanceGroupResource)(nil)
+)
+
+// NewInstanceGroupResource is a helper function to simplify the provider implementation.
+func NewInstanceGroupResource() resource.Resource {
+	return &instanceGroupResource{}
+}
+
+// instanceGroupResource is the resource implementation.
+//
+// Because Oxide has no server-side instance template object for the
+// provider to read back (see oxide_instance_template), the group carries
+// its own copy of the instance spec. In practice a caller sets these
+// attributes from the template resource's own attributes (e.g.
+// `memory = oxide_instance_template.web.memory`) so the two stay in sync
+// through Terraform's normal dependency graph instead of a runtime lookup.
+type instanceGroupResource struct {
+	client *oxideSDK.Client
+}
+
+type instanceGroupResourceModel struct {
+	AttachedDisks    types.List     `tfsdk:"attached_disks"`
+	BaseInstanceName types.String   `tfsdk:"base_instance_name"`
+	BootDisk         types.Object   `tfsdk:"boot_disk"`
+	Description      types.String   `tfsdk:"description"`
+	ExternalIPs      types.List     `tfsdk:"external_ips"`
+	ID               types.String   `tfsdk:"id"`
+	InstanceIDs      types.List     `tfsdk:"instance_ids"`
+	Memory           types.Int64    `tfsdk:"memory"`
+	NCPUs            types.Int64    `tfsdk:"ncpus"`
+	ProjectID        types.String   `tfsdk:"project_id"`
+	TargetSize       types.Int64    `tfsdk:"target_size"`
+	TemplateID       types.String   `tfsdk:"template_id"`
+	Timeouts         timeouts.Value `tfsdk:"timeouts"`
+	UpdatePolicy     types.String   `tfsdk:"update_policy"`
+	UserData         types.String   `tfsdk:"user_data"`
+}
+
+const (
+	instanceGroupUpdatePolicyRecreate       = "recreate"
+	instanceGroupUpdatePolicyRollingReplace = "rolling-replace"
+)
+
+// Metadata returns the resource type name.
+func (r *instanceGroupResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "oxide_instance_group"
+}
+
+// Configure adds the provider configured client to the data source.
+func (r *instanceGroupResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*oxideSDK.Client)
+}
+
+func (r *instanceGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// Schema defines the schema for the resource.
+func (r *instanceGroupResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Materializes a fleet of identical instances from the group's own instance attributes, typically copied from an oxide_instance_template.",
+		Attributes: map[string]schema.Attribute{
+			"project_id": schema.StringAttribute{
+				Required:    true,
+				Description: "ID of the project that will contain the instances.",
+			},
+			"template_id": schema.StringAttribute{
+				Required: true,
+				Description: "ID of the oxide_instance_template this group is associated with. The group does not read the template: " +
+					"its instances are created from the group's own attributes, and a change to template_id alone does not replace them.",
+			},
+			"base_instance_name": schema.StringAttribute{
+				Required:    true,
+				Description: "Prefix used to name each instance, e.g. \"web\" produces web-000, web-001, ...",
+			},
+			"target_size": schema.Int64Attribute{
+				Required:    true,
+				Description: "Number of instances the group should maintain.",
+			},
+			"update_policy": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(instanceGroupUpdatePolicyRecreate),
+				Description: "How to apply spec changes to already-running instances: \"recreate\" tears down and " +
+					"recreates every managed instance without waiting between them, \"rolling-replace\" replaces " +
+					"one instance at a time, waiting for each replacement to reach the running state before " +
+					"moving to the next. Defaults to \"recreate\".",
+				Validators: []validator.String{
+					stringvalidator.OneOf(instanceGroupUpdatePolicyRecreate, instanceGroupUpdatePolicyRollingReplace),
+				},
+			},
+			"description": descriptionSchemaAttribute(),
+			"memory":      memorySchemaAttribute(),
+			"ncpus":       ncpusSchemaAttribute(),
+			"user_data":   userDataSchemaAttribute(),
+			"boot_disk": schema.SingleNestedAttribute{
+				Required:    true,
+				Description: "Boot disk attached to every instance in the group.",
+				Attributes: map[string]schema.Attribute{
+					"disk_id": schema.StringAttribute{
+						Required:    true,
+						Description: "ID of the disk instances should boot from.",
+					},
+					"device_name": schema.StringAttribute{
+						Required:    true,
+						Description: "Device name of the boot disk as it is attached to the instance.",
+					},
+				},
+			},
+			"attached_disks": schema.ListNestedAttribute{
+				Optional:    true,
+				Description: "Additional disks attached to every instance in the group.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"disk_id": schema.StringAttribute{
+							Required:    true,
+							Description: "ID of the disk to attach.",
+						},
+						"device_name": schema.StringAttribute{
+							Required:    true,
+							Description: "Device name of the disk as it is attached to the instance.",
+						},
+					},
+				},
+			},
+			"external_ips": schema.ListAttribute{
+				Optional:    true,
+				Description: "External IP pools every instance in the group should draw addresses from.",
+				ElementType: types.StringType,
+			},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Unique, immutable identifier of the group.",
+			},
+			"instance_ids": schema.ListAttribute{
+				Computed:    true,
+				Description: "IDs of the instances currently managed by this group, in creation order.",
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *instanceGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan instanceGroupResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultTimeout())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	id, err := newTemplateID()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating instance group",
+			"ID generation error: "+err.Error(),
+		)
+		return
+	}
+	plan.ID = types.StringValue(id)
+
+	instanceIDs, ok := r.createInstances(ctx, &plan, 0, int(plan.TargetSize.ValueInt64()), &resp.Diagnostics)
+	if !ok {
+		return
+	}
+
+	idList, diags := types.ListValueFrom(ctx, types.StringType, instanceIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.InstanceIDs = idList
+
+	tflog.Trace(ctx, fmt.Sprintf("created instance group with ID: %v", id), map[string]any{"success": true})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *instanceGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state instanceGroupResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultTimeout())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	var instanceIDs []string
+	resp.Diagnostics.Append(state.InstanceIDs.ElementsAs(ctx, &instanceIDs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	live := make([]string, 0, len(instanceIDs))
+	for _, id := range instanceIDs {
+		if _, err := r.client.InstanceView(oxideSDK.InstanceViewParams{Instance: oxideSDK.NameOrId(id)}); err != nil {
+			if is404(err) {
+				continue
+			}
+			resp.Diagnostics.AddError(
+				"Unable to read instance:",
+				"API error: "+err.Error(),
+			)
+			return
+		}
+		live = append(live, id)
+	}
+
+	idList, diags := types.ListValueFrom(ctx, types.StringType, live)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.InstanceIDs = idList
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+//
+// Scaling (a target_size change) adds or removes instances at the current
+// spec. A spec change (memory, ncpus, boot_disk, ...) replaces every
+// managed instance according to update_policy.
+func (r *instanceGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state instanceGroupResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultTimeout())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	var instanceIDs []string
+	resp.Diagnostics.Append(state.InstanceIDs.ElementsAs(ctx, &instanceIDs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	specChanged := !plan.Memory.Equal(state.Memory) ||
+		!plan.NCPUs.Equal(state.NCPUs) ||
+		!plan.BootDisk.Equal(state.BootDisk) ||
+		!plan.AttachedDisks.Equal(state.AttachedDisks) ||
+		!plan.ExternalIPs.Equal(state.ExternalIPs) ||
+		!plan.UserData.Equal(state.UserData) ||
+		!plan.Description.Equal(state.Description)
+
+	if specChanged {
+		if plan.UpdatePolicy.ValueString() == instanceGroupUpdatePolicyRollingReplace {
+			survivors := make([]string, 0, len(instanceIDs))
+			nextIndex := len(instanceIDs)
+			for _, id := range instanceIDs {
+				if !r.deleteInstance(ctx, id, updateTimeout, &resp.Diagnostics) {
+					return
+				}
+				replacement, ok := r.createInstances(ctx, &plan, nextIndex, nextIndex+1, &resp.Diagnostics)
+				if !ok {
+					return
+				}
+				nextIndex++
+				if !r.waitInstanceRunning(ctx, replacement[0], updateTimeout, &resp.Diagnostics) {
+					return
+				}
+				survivors = append(survivors, replacement...)
+			}
+			instanceIDs = survivors
+		} else {
+			for _, id := range instanceIDs {
+				if !r.deleteInstance(ctx, id, updateTimeout, &resp.Diagnostics) {
+					return
+				}
+			}
+			created, ok := r.createInstances(ctx, &plan, 0, int(plan.TargetSize.ValueInt64()), &resp.Diagnostics)
+			if !ok {
+				return
+			}
+			instanceIDs = created
+		}
+	} else if int64(len(instanceIDs)) != plan.TargetSize.ValueInt64() {
+		if delta := int(plan.TargetSize.ValueInt64()) - len(instanceIDs); delta > 0 {
+			created, ok := r.createInstances(ctx, &plan, len(instanceIDs), len(instanceIDs)+delta, &resp.Diagnostics)
+			if !ok {
+				return
+			}
+			instanceIDs = append(instanceIDs, created...)
+		} else {
+			toRemove := instanceIDs[len(instanceIDs)+delta:]
+			instanceIDs = instanceIDs[:len(instanceIDs)+delta]
+			for _, id := range toRemove {
+				if !r.deleteInstance(ctx, id, updateTimeout, &resp.Diagnostics) {
+					return
+				}
+			}
+		}
+	}
+
+	idList, diags := types.ListValueFrom(ctx, types.StringType, instanceIDs)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.ID = state.ID
+	plan.InstanceIDs = idList
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *instanceGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state instanceGroupResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultTimeout())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	var instanceIDs []string
+	resp.Diagnostics.Append(state.InstanceIDs.ElementsAs(ctx, &instanceIDs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, id := range instanceIDs {
+		if !r.deleteInstance(ctx, id, deleteTimeout, &resp.Diagnostics) {
+			return
+		}
+	}
+}
+
+// instanceGroupDiskModel is a disk reference shared by the boot_disk and
+// attached_disks attributes.
+type instanceGroupDiskModel struct {
+	DiskID     types.String `tfsdk:"disk_id"`
+	DeviceName types.String `tfsdk:"device_name"`
+}
+
+// createInstances creates instances [from, to) for plan's spec, returning
+// the IDs of the instances created. It reports any error via diags and
+// returns ok=false so the caller can bail out.
+func (r *instanceGroupResource) createInstances(ctx context.Context, plan *instanceGroupResourceModel, from, to int, diags *diag.Diagnostics) ([]string, bool) {
+	var bootDisk instanceGroupDiskModel
+	diags.Append(plan.BootDisk.As(ctx, &bootDisk, basetypes.ObjectAsOptions{})...)
+
+	var attachedDisks []instanceGroupDiskModel
+	diags.Append(plan.AttachedDisks.ElementsAs(ctx, &attachedDisks, false)...)
+
+	var externalIPs []string
+	diags.Append(plan.ExternalIPs.ElementsAs(ctx, &externalIPs, false)...)
+	if diags.HasError() {
+		return nil, false
+	}
+
+	diskAttachments := []oxideSDK.InstanceDiskAttachment{
+		{Name: oxideSDK.Name(bootDisk.DiskID.ValueString()), Type: oxideSDK.InstanceDiskAttachmentTypeAttach},
+	}
+	for _, d := range attachedDisks {
+		diskAttachments = append(diskAttachments, oxideSDK.InstanceDiskAttachment{
+			Name: oxideSDK.Name(d.DiskID.ValueString()),
+			Type: oxideSDK.InstanceDiskAttachmentTypeAttach,
+		})
+	}
+
+	externalIPCreates := make([]oxideSDK.ExternalIpCreate, 0, len(externalIPs))
+	for _, pool := range externalIPs {
+		externalIPCreates = append(externalIPCreates, oxideSDK.ExternalIpCreate{
+			PoolName: oxideSDK.Name(pool),
+			Type:     oxideSDK.ExternalIpCreateTypeEphemeral,
+		})
+	}
+
+	ids := make([]string, 0, to-from)
+	for i := from; i < to; i++ {
+		name := fmt.Sprintf("%s-%03d", plan.BaseInstanceName.ValueString(), i)
+
+		instance, err := r.client.InstanceCreate(oxideSDK.InstanceCreateParams{
+			Project: oxideSDK.NameOrId(plan.ProjectID.ValueString()),
+			Body: &oxideSDK.InstanceCreate{
+				Description: plan.Description.ValueString(),
+				Name:        oxideSDK.Name(name),
+				Hostname:    name,
+				Memory:      oxideSDK.ByteCount(plan.Memory.ValueInt64()),
+				Ncpus:       oxideSDK.InstanceCpuCount(plan.NCPUs.ValueInt64()),
+				Start:       true,
+				NetworkInterfaces: oxideSDK.InstanceNetworkInterfaceAttachment{
+					Type: oxideSDK.InstanceNetworkInterfaceAttachmentTypeNone,
+				},
+				UserData:    plan.UserData.ValueString(),
+				Disks:       diskAttachments,
+				ExternalIps: externalIPCreates,
+			},
+		})
+		if err != nil {
+			diags.AddError(
+				"Unable to create instance:",
+				"API error: "+err.Error(),
+			)
+			return nil, false
+		}
+		ids = append(ids, instance.Id)
+	}
+
+	return ids, true
+}
+
+// deleteInstance stops and deletes a single instance, waiting for it to
+// reach the stopped state before issuing the delete. It reports any error
+// via diags and returns false so the caller can bail out.
+func (r *instanceGroupResource) deleteInstance(ctx context.Context, id string, timeout time.Duration, diags *diag.Diagnostics) bool {
+	instanceID := oxideSDK.NameOrId(id)
+
+	if _, err := r.client.InstanceStop(oxideSDK.InstanceStopParams{Instance: instanceID}); err != nil {
+		if !is404(err) {
+			diags.AddError(
+				"Unable to stop instance:",
+				"API error: "+err.Error(),
+			)
+			return false
+		}
+	}
+
+	stopWaiter := wait.Waiter{
+		Pending:        []string{string(oxideSDK.InstanceStateRunning), string(oxideSDK.InstanceStateStopping)},
+		Target:         []string{string(oxideSDK.InstanceStateStopped)},
+		Refresh:        instanceRefresh(r.client, instanceID),
+		Timeout:        timeout,
+		MinTimeout:     5 * time.Second,
+		PollInterval:   time.Second,
+		NotFoundChecks: 3,
+	}
+	if _, err := stopWaiter.WaitForStateContext(ctx); err != nil {
+		diags.AddError(
+			"Unable to stop instance:",
+			"API error: "+err.Error(),
+		)
+		return false
+	}
+
+	if err := r.client.InstanceDelete(oxideSDK.InstanceDeleteParams{Instance: instanceID}); err != nil {
+		if !is404(err) {
+			diags.AddError(
+				"Unable to delete instance:",
+				"API error: "+err.Error(),
+			)
+			return false
+		}
+	}
+
+	return true
+}
+
+// waitInstanceRunning waits for id, already created with Start: true, to
+// reach the running state. Rolling replacement relies on this to avoid
+// moving on to the next instance before the current replacement is actually
+// up. It reports any error via diags and returns false so the caller can
+// bail out.
+func (r *instanceGroupResource) waitInstanceRunning(ctx context.Context, id string, timeout time.Duration, diags *diag.Diagnostics) bool {
+	instanceID := oxideSDK.NameOrId(id)
+
+	runningWaiter := wait.Waiter{
+		Pending:        []string{string(oxideSDK.InstanceStateStopped), string(oxideSDK.InstanceStateStarting)},
+		Target:         []string{string(oxideSDK.InstanceStateRunning)},
+		Refresh:        instanceRefresh(r.client, instanceID),
+		Timeout:        timeout,
+		MinTimeout:     5 * time.Second,
+		PollInterval:   time.Second,
+		NotFoundChecks: 0,
+	}
+	if _, err := runningWaiter.WaitForStateContext(ctx); err != nil {
+		diags.AddError(
+			"Unable to wait for instance to start:",
+			"API error: "+err.Error(),
+		)
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,120 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package oxide
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// TestAccResourceInstanceGroup_full exercises the default update_policy
+// ("recreate"): create at target_size 2, scale up to 3, scale down to 1,
+// then a spec change (memory), each of which takes a different branch of
+// Update.
+func TestAccResourceInstanceGroup_full(t *testing.T) {
+	resourceName := "oxide_instance_group.test"
+	diskID := testAccInstanceDiskID(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceInstanceGroupConfig(diskID, 2, "", 1073741824),
+				Check:  checkResourceInstanceGroup(resourceName, 2),
+			},
+			{
+				// Scale up.
+				Config: testResourceInstanceGroupConfig(diskID, 3, "", 1073741824),
+				Check:  checkResourceInstanceGroup(resourceName, 3),
+			},
+			{
+				// Scale down.
+				Config: testResourceInstanceGroupConfig(diskID, 1, "", 1073741824),
+				Check:  checkResourceInstanceGroup(resourceName, 1),
+			},
+			{
+				// Spec change: every instance is torn down and recreated.
+				Config: testResourceInstanceGroupConfig(diskID, 1, "", 2147483648),
+				Check:  checkResourceInstanceGroup(resourceName, 1),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// TestAccResourceInstanceGroup_rollingReplace exercises update_policy =
+// "rolling-replace": a spec change should replace instances one at a time,
+// leaving target_size instances running and none of the original ids
+// behind.
+func TestAccResourceInstanceGroup_rollingReplace(t *testing.T) {
+	resourceName := "oxide_instance_group.test"
+	diskID := testAccInstanceDiskID(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceInstanceGroupConfig(diskID, 2, "rolling-replace", 1073741824),
+				Check:  checkResourceInstanceGroup(resourceName, 2),
+			},
+			{
+				Config: testResourceInstanceGroupConfig(diskID, 2, "rolling-replace", 2147483648),
+				Check:  checkResourceInstanceGroup(resourceName, 2),
+			},
+		},
+	})
+}
+
+func testResourceInstanceGroupConfig(diskID string, targetSize int, updatePolicy string, memory int) string {
+	updatePolicyAttr := ""
+	if updatePolicy != "" {
+		updatePolicyAttr = fmt.Sprintf("\tupdate_policy      = %q\n", updatePolicy)
+	}
+
+	return fmt.Sprintf(`
+resource "oxide_instance_template" "test" {
+	project_id      = "terraform-acc-myproject"
+	name            = "terraform-acc-mytemplate"
+	description     = "a test template"
+	hostname_prefix = "web"
+	memory          = %[4]d
+	ncpus           = 1
+	boot_disk = {
+		disk_id     = %[1]q
+		device_name = "root"
+	}
+}
+
+resource "oxide_instance_group" "test" {
+	project_id          = "terraform-acc-myproject"
+	template_id         = oxide_instance_template.test.id
+	base_instance_name  = "terraform-acc-web"
+	target_size         = %[2]d
+	description         = oxide_instance_template.test.description
+	memory              = oxide_instance_template.test.memory
+	ncpus               = oxide_instance_template.test.ncpus
+%[3]s	boot_disk = {
+		disk_id     = %[1]q
+		device_name = "root"
+	}
+}
+`, diskID, targetSize, updatePolicyAttr, memory)
+}
+
+func checkResourceInstanceGroup(resourceName string, targetSize int) resource.TestCheckFunc {
+	return resource.ComposeAggregateTestCheckFunc([]resource.TestCheckFunc{
+		resource.TestCheckResourceAttrSet(resourceName, "id"),
+		resource.TestCheckResourceAttr(resourceName, "target_size", fmt.Sprintf("%d", targetSize)),
+		resource.TestCheckResourceAttr(resourceName, "instance_ids.#", fmt.Sprintf("%d", targetSize)),
+	}...)
+}
@@ -0,0 +1,316 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package oxide
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	oxideSDK "github.com/oxidecomputer/oxide.go/oxide"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = (*instanceTemplateResource)(nil)
+	_ resource.ResourceWithConfigure = (*instanceTemplateResource)(nil)
+)
+
+// NewInstanceTemplateResource is a helper function to simplify the provider implementation.
+func NewInstanceTemplateResource() resource.Resource {
+	return &instanceTemplateResource{}
+}
+
+// instanceTemplateResource is the resource implementation.
+//
+// Unlike oxide_instance, an instance template has no backing object in the
+// Oxide API: Oxide does not have a server-side "instance template" concept
+// the way GCP does. The template is therefore a purely Terraform-side
+// blueprint -- its "id" is generated locally and its fields are persisted
+// only in Terraform state. oxide_instance_group does not read it: a
+// configuration copies the template's attributes into the group's own
+// attributes, and the group passes those to InstanceCreate.
+//
+// This is also why there is no oxide_instance_template data source: a data
+// source can only read an external system, and there is no external system
+// here to read -- the blueprint lives in another resource's Terraform
+// state, which data sources have no access to. Cross-referencing a template
+// from another configuration isn't supported; reference the resource's own
+// attributes within the same configuration instead.
+type instanceTemplateResource struct {
+	client *oxideSDK.Client
+}
+
+type instanceTemplateResourceModel struct {
+	AttachedDisks     types.List     `tfsdk:"attached_disks"`
+	BootDisk          types.Object   `tfsdk:"boot_disk"`
+	Description       types.String   `tfsdk:"description"`
+	ExternalIPs       types.List     `tfsdk:"external_ips"`
+	HostnamePrefix    types.String   `tfsdk:"hostname_prefix"`
+	ID                types.String   `tfsdk:"id"`
+	Labels            types.Map      `tfsdk:"labels"`
+	Memory            types.Int64    `tfsdk:"memory"`
+	Name              types.String   `tfsdk:"name"`
+	NCPUs             types.Int64    `tfsdk:"ncpus"`
+	NetworkInterfaces types.List     `tfsdk:"network_interfaces"`
+	ProjectID         types.String   `tfsdk:"project_id"`
+	Timeouts          timeouts.Value `tfsdk:"timeouts"`
+	UserData          types.String   `tfsdk:"user_data"`
+}
+
+// instanceTemplateBootDiskModel is the template's boot_disk block. It holds
+// only disk_id and device_name, the same two fields as oxide_instance_group's
+// boot_disk; oxide_instance's boot_disk also has auto_delete and
+// initialize_params, which a template does not.
+type instanceTemplateBootDiskModel struct {
+	DiskID     types.String `tfsdk:"disk_id"`
+	DeviceName types.String `tfsdk:"device_name"`
+}
+
+// instanceTemplateAttachedDiskModel is a single entry of attached_disks.
+type instanceTemplateAttachedDiskModel struct {
+	DiskID     types.String `tfsdk:"disk_id"`
+	DeviceName types.String `tfsdk:"device_name"`
+}
+
+// instanceTemplateNetworkInterfaceModel is a single entry of
+// network_interfaces.
+type instanceTemplateNetworkInterfaceModel struct {
+	SubnetID types.String `tfsdk:"subnet_id"`
+	VpcID    types.String `tfsdk:"vpc_id"`
+}
+
+// Metadata returns the resource type name.
+func (r *instanceTemplateResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "oxide_instance_template"
+}
+
+// Configure adds the provider configured client to the data source.
+func (r *instanceTemplateResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*oxideSDK.Client)
+}
+
+func (r *instanceTemplateResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// Schema defines the schema for the resource.
+//
+// Every attribute here other than timeouts and the computed id carries
+// RequiresReplace: templates are immutable
+// blueprints, same as google_compute_instance_template, so any change to
+// any field replaces the template (and its id) rather than updating it in
+// place.
+func (r *instanceTemplateResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	replaceString := []planmodifier.String{stringplanmodifier.RequiresReplace()}
+
+	resp.Schema = schema.Schema{
+		Description: "An immutable blueprint describing how to create instances. Use with oxide_instance_group " +
+			"to manage a fleet of identical instances.",
+		Attributes: map[string]schema.Attribute{
+			"project_id": schema.StringAttribute{
+				Required:      true,
+				Description:   "ID of the project that will contain instances created from this template.",
+				PlanModifiers: replaceString,
+			},
+			"name": schema.StringAttribute{
+				Required:      true,
+				Description:   "Name of the template.",
+				PlanModifiers: replaceString,
+			},
+			"description": schema.StringAttribute{
+				Required:      true,
+				Description:   "Description for the template.",
+				PlanModifiers: replaceString,
+			},
+			"hostname_prefix": schema.StringAttribute{
+				Required:      true,
+				Description:   "Prefix for the host names of instances created from this template. Recorded in state only: oxide_instance_group has no matching attribute and names each instance's host after its own base_instance_name.",
+				PlanModifiers: replaceString,
+			},
+			"memory": schema.Int64Attribute{
+				Required:      true,
+				Description:   "Instance memory in bytes.",
+				PlanModifiers: []planmodifier.Int64{int64planmodifier.RequiresReplace()},
+			},
+			"ncpus": schema.Int64Attribute{
+				Required:      true,
+				Description:   "Number of CPUs allocated for each instance.",
+				PlanModifiers: []planmodifier.Int64{int64planmodifier.RequiresReplace()},
+			},
+			"boot_disk": schema.SingleNestedAttribute{
+				Required:    true,
+				Description: "Boot disk attached to instances created from this template.",
+				Attributes: map[string]schema.Attribute{
+					"disk_id": schema.StringAttribute{
+						Required:    true,
+						Description: "ID of the disk instances should boot from.",
+					},
+					"device_name": schema.StringAttribute{
+						Required:    true,
+						Description: "Device name of the boot disk as it is attached to the instance.",
+					},
+				},
+				PlanModifiers: []planmodifier.Object{objectplanmodifier.RequiresReplace()},
+			},
+			"attached_disks": schema.ListNestedAttribute{
+				Optional:    true,
+				Description: "Additional disks attached to instances created from this template.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"disk_id": schema.StringAttribute{
+							Required:    true,
+							Description: "ID of the disk to attach.",
+						},
+						"device_name": schema.StringAttribute{
+							Required:    true,
+							Description: "Device name of the disk as it is attached to the instance.",
+						},
+					},
+				},
+				PlanModifiers: []planmodifier.List{listplanmodifier.RequiresReplace()},
+			},
+			"network_interfaces": schema.ListNestedAttribute{
+				Optional:    true,
+				Description: "Network interfaces for instances created from this template. Recorded in state only: oxide_instance_group has no matching attribute and creates its instances without network interfaces.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"vpc_id": schema.StringAttribute{
+							Required:    true,
+							Description: "ID of the VPC the interface belongs to.",
+						},
+						"subnet_id": schema.StringAttribute{
+							Required:    true,
+							Description: "ID of the subnet the interface belongs to.",
+						},
+					},
+				},
+				PlanModifiers: []planmodifier.List{listplanmodifier.RequiresReplace()},
+			},
+			"external_ips": schema.ListAttribute{
+				Optional:      true,
+				Description:   "External IP pools instances created from this template should draw addresses from.",
+				ElementType:   types.StringType,
+				PlanModifiers: []planmodifier.List{listplanmodifier.RequiresReplace()},
+			},
+			"labels": schema.MapAttribute{
+				Optional:      true,
+				Description:   "Labels for instances created from this template. Recorded in state only: oxide_instance_group has no matching attribute, so the labels are not applied to any instance.",
+				ElementType:   types.StringType,
+				PlanModifiers: []planmodifier.Map{mapplanmodifier.RequiresReplace()},
+			},
+			"user_data": schema.StringAttribute{
+				Optional:      true,
+				Description:   "Base64-encoded user data applied to instances created from this template.",
+				PlanModifiers: replaceString,
+			},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Delete: true,
+			}),
+			"id": schema.StringAttribute{
+				Computed:      true,
+				Description:   "Unique, immutable identifier of the template.",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+		},
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+//
+// There is no remote object to create: Create's only job is to mint a
+// stable id and persist the plan as-is, the same role random_id's Create
+// plays for locally-generated values.
+func (r *instanceTemplateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan instanceTemplateResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := newTemplateID()
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating instance template",
+			"ID generation error: "+err.Error(),
+		)
+		return
+	}
+	plan.ID = types.StringValue(id)
+
+	tflog.Trace(ctx, fmt.Sprintf("created instance template with ID: %v", id), map[string]any{"success": true})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+//
+// Templates have no remote source of truth, so Read is a no-op: whatever is
+// already in state is still correct.
+func (r *instanceTemplateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state instanceTemplateResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+//
+// Every attribute other than timeouts carries RequiresReplace, so a change
+// to timeouts alone is the only change that reaches Update; it is saved to
+// state with the template's id kept as is.
+func (r *instanceTemplateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state instanceTemplateResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = state.ID
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+//
+// There is no remote object to delete; removing it from state is sufficient.
+func (r *instanceTemplateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+// newTemplateID generates a random, locally-unique identifier for a
+// template, since Oxide has no server-side object to mint one for us.
+func newTemplateID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,61 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package oxide
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccResourceInstanceTemplate_full(t *testing.T) {
+	resourceName := "oxide_instance_template.test"
+	diskID := testAccInstanceDiskID(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceInstanceTemplateConfig(diskID),
+				Check:  checkResourceInstanceTemplate(resourceName),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testResourceInstanceTemplateConfig(diskID string) string {
+	return fmt.Sprintf(`
+resource "oxide_instance_template" "test" {
+	project_id       = "terraform-acc-myproject"
+	name             = "terraform-acc-mytemplate"
+	description      = "a test template"
+	hostname_prefix  = "web"
+	memory           = 1073741824
+	ncpus            = 1
+	boot_disk = {
+		disk_id     = %[1]q
+		device_name = "root"
+	}
+}
+`, diskID)
+}
+
+func checkResourceInstanceTemplate(resourceName string) resource.TestCheckFunc {
+	return resource.ComposeAggregateTestCheckFunc([]resource.TestCheckFunc{
+		resource.TestCheckResourceAttrSet(resourceName, "id"),
+		resource.TestCheckResourceAttr(resourceName, "name", "terraform-acc-mytemplate"),
+		resource.TestCheckResourceAttr(resourceName, "hostname_prefix", "web"),
+		resource.TestCheckResourceAttr(resourceName, "memory", "1073741824"),
+		resource.TestCheckResourceAttr(resourceName, "ncpus", "1"),
+		resource.TestCheckResourceAttr(resourceName, "boot_disk.device_name", "root"),
+	}...)
+}
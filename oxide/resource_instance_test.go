@@ -0,0 +1,132 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package oxide
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	oxideSDK "github.com/oxidecomputer/oxide.go/oxide"
+)
+
+// Exercising boot_disk.disk_id and attached_disk.disk_id needs a disk that
+// already exists; there's no oxide_disk resource in this provider yet to
+// create one inline, so the test reads its id from the environment like the
+// rest of this repo's acceptance tests read their project/VPC fixtures.
+func testAccInstanceDiskID(t *testing.T) string {
+	id := os.Getenv("OXIDE_TEST_DISK_ID")
+	if id == "" {
+		t.Skip("OXIDE_TEST_DISK_ID must be set to run " + t.Name())
+	}
+	return id
+}
+
+func TestAccResourceInstance_full(t *testing.T) {
+	resourceName := "oxide_instance.test"
+	diskID := testAccInstanceDiskID(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(),
+		CheckDestroy:             testAccInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testResourceInstanceConfig(diskID),
+				Check:  checkResourceInstance(resourceName),
+			},
+			{
+				// Changes memory and ncpus, which requires stopping the
+				// (running) instance; preserve_run_state = false means it
+				// should come back out stopped rather than restarted.
+				Config: testResourceInstanceUpdateConfig(diskID),
+				Check:  checkResourceInstanceUpdate(resourceName),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testResourceInstanceConfig(diskID string) string {
+	return fmt.Sprintf(`
+resource "oxide_instance" "test" {
+	project_id  = "terraform-acc-myproject"
+	name        = "terraform-acc-myinstance"
+	description = "a test instance"
+	host_name   = "terraform-acc-myinstance"
+	memory      = 1073741824
+	ncpus       = 1
+	boot_disk = {
+		disk_id     = %[1]q
+		device_name = "root"
+	}
+}
+`, diskID)
+}
+
+func checkResourceInstance(resourceName string) resource.TestCheckFunc {
+	return resource.ComposeAggregateTestCheckFunc([]resource.TestCheckFunc{
+		resource.TestCheckResourceAttrSet(resourceName, "id"),
+		resource.TestCheckResourceAttr(resourceName, "name", "terraform-acc-myinstance"),
+		resource.TestCheckResourceAttr(resourceName, "memory", "1073741824"),
+		resource.TestCheckResourceAttr(resourceName, "ncpus", "1"),
+		resource.TestCheckResourceAttr(resourceName, "boot_disk.device_name", "root"),
+		resource.TestCheckResourceAttrSet(resourceName, "time_created"),
+		resource.TestCheckResourceAttrSet(resourceName, "time_modified"),
+	}...)
+}
+
+func testResourceInstanceUpdateConfig(diskID string) string {
+	return fmt.Sprintf(`
+resource "oxide_instance" "test" {
+	project_id         = "terraform-acc-myproject"
+	name               = "terraform-acc-myinstance"
+	description        = "a test instance"
+	host_name          = "terraform-acc-myinstance"
+	memory             = 2147483648
+	ncpus              = 2
+	preserve_run_state = false
+	boot_disk = {
+		disk_id     = %[1]q
+		device_name = "root"
+	}
+}
+`, diskID)
+}
+
+func checkResourceInstanceUpdate(resourceName string) resource.TestCheckFunc {
+	return resource.ComposeAggregateTestCheckFunc([]resource.TestCheckFunc{
+		resource.TestCheckResourceAttr(resourceName, "memory", "2147483648"),
+		resource.TestCheckResourceAttr(resourceName, "ncpus", "2"),
+		resource.TestCheckResourceAttr(resourceName, "preserve_run_state", "false"),
+	}...)
+}
+
+func testAccInstanceDestroy(s *terraform.State) error {
+	client, err := newTestClient()
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "oxide_instance" {
+			continue
+		}
+
+		res, err := client.InstanceView(oxideSDK.InstanceViewParams{Instance: oxideSDK.NameOrId(rs.Primary.ID)})
+		if err != nil && is404(err) {
+			continue
+		}
+		return fmt.Errorf("instance (%v) still exists", &res.Name)
+	}
+
+	return nil
+}
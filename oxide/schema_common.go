@@ -0,0 +1,67 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package oxide
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// Schema attribute builders shared between oxide_instance and
+// oxide_instance_group so that the two resources' notion of "what makes
+// up an instance" cannot drift apart. oxide_instance_template declares its
+// own attributes and does not use these builders. Apart from
+// nameSchemaAttribute's description, the builders take no options; a caller
+// that needs an extra validator or plan modifier adds it to the returned
+// attribute.
+
+// nameSchemaAttribute returns the schema for an instance's name.
+func nameSchemaAttribute(description string) schema.StringAttribute {
+	return schema.StringAttribute{
+		Required:    true,
+		Description: description,
+	}
+}
+
+// descriptionSchemaAttribute returns the schema for an instance or
+// instance group's description.
+func descriptionSchemaAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Required:    true,
+		Description: "Description for the instance.",
+	}
+}
+
+// memorySchemaAttribute returns the schema for an instance or instance
+// group's memory, in bytes.
+func memorySchemaAttribute() schema.Int64Attribute {
+	return schema.Int64Attribute{
+		Required:    true,
+		Description: "Instance memory in bytes.",
+	}
+}
+
+// ncpusSchemaAttribute returns the schema for an instance or instance
+// group's CPU count.
+func ncpusSchemaAttribute() schema.Int64Attribute {
+	return schema.Int64Attribute{
+		Required:    true,
+		Description: "Number of CPUs allocated for this instance.",
+	}
+}
+
+// userDataSchemaAttribute returns the schema for an instance or instance
+// group's user data.
+func userDataSchemaAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional: true,
+		Description: "User data for instance initialization systems (such as cloud-init). " +
+			"Must be a Base64-encoded string, as specified in RFC 4648 § 4 (+ and / characters with padding). " +
+			"Maximum 32 KiB unencoded data.",
+		Validators: []validator.String{
+			base64UserDataSizeValidator{},
+		},
+	}
+}
@@ -0,0 +1,76 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package oxide
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// maxUserDataSize is the largest unencoded user data payload Oxide accepts,
+// enforced here at plan time so a misconfigured instance fails with a clear
+// diagnostic instead of a server-side rejection at apply time.
+const maxUserDataSize = 32 * 1024 // 32 KiB
+
+// base64UserDataSizeValidator enforces maxUserDataSize on an
+// already-base64-encoded string, such as user_data.
+type base64UserDataSizeValidator struct{}
+
+func (v base64UserDataSizeValidator) Description(_ context.Context) string {
+	return "value must be valid Base64 that decodes to at most 32 KiB"
+}
+
+func (v base64UserDataSizeValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v base64UserDataSizeValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(req.ConfigValue.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid user_data", "user_data must be valid Base64: "+err.Error())
+		return
+	}
+
+	if len(decoded) > maxUserDataSize {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"user_data too large",
+			fmt.Sprintf("user_data must decode to at most %d bytes (32 KiB), got %d", maxUserDataSize, len(decoded)),
+		)
+	}
+}
+
+// rawUserDataSizeValidator enforces maxUserDataSize on raw, not-yet-encoded
+// text such as metadata_startup_script.
+type rawUserDataSizeValidator struct{}
+
+func (v rawUserDataSizeValidator) Description(_ context.Context) string {
+	return "value must be at most 32 KiB"
+}
+
+func (v rawUserDataSizeValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v rawUserDataSizeValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if size := len(req.ConfigValue.ValueString()); size > maxUserDataSize {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"metadata_startup_script too large",
+			fmt.Sprintf("metadata_startup_script must be at most %d bytes (32 KiB), got %d", maxUserDataSize, size),
+		)
+	}
+}